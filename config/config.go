@@ -8,6 +8,10 @@ type MainConfig struct {
 	Rdbms       RdbmsConfig       `fig:"rdbms"`
 	Otel        OtelConfig        `fig:"otel"`
 	Watermill   WatermillConfig   `fig:"watermill"`
+	Inventory   InventoryConfig   `fig:"inventory"`
+	Product     ProductConfig     `fig:"product"`
+	Storage     StorageConfig     `fig:"storage"`
+	Auth        AuthConfig        `fig:"auth"`
 }
 
 type (
@@ -24,9 +28,34 @@ type (
 
 		Rest ServerConfigRest `fig:"rest"`
 
-		GraphQL struct {
-			Port int `fig:"port"`
-		} `fig:"graphql"`
+		GraphQL ServerConfigGraphQL `fig:"graphql"`
+	}
+
+	ServerConfigGraphQL struct {
+		Port int `fig:"port"`
+		// DataloaderWaitMs is how long the dataloaders collect keys before loading them in a batch.
+		DataloaderWaitMs int `fig:"dataloaderWaitMs"`
+		// DataloaderBatchCapacity is the maximum number of keys the dataloaders load in a batch. Zero means unlimited.
+		DataloaderBatchCapacity int `fig:"dataloaderBatchCapacity"`
+		// MaxDepth is the maximum nesting of the fields of an operation. Zero uses the default of 12.
+		MaxDepth int `fig:"maxDepth"`
+		// MaxComplexity is the maximum complexity of an operation, where list fields cost the cost of their items
+		// times their page size. Zero uses the default of 5000.
+		MaxComplexity int `fig:"maxComplexity"`
+		// ExposeInternalErrors shows the messages of unexpected errors to clients instead of a generic message.
+		// It is meant for local development and must stay off in production.
+		ExposeInternalErrors bool `fig:"exposeInternalErrors"`
+		// TrustedDocuments restricts the operations to the ones of a build-time manifest.
+		TrustedDocuments ServerConfigGraphQLTrustedDocuments `fig:"trustedDocuments"`
+	}
+
+	ServerConfigGraphQLTrustedDocuments struct {
+		// Enabled rejects the operations that are not in the manifest. Automatic persisted queries are disabled then.
+		Enabled bool `fig:"enabled"`
+		// ManifestPath is the JSON file of hashes to documents, or an Apollo persisted query manifest.
+		ManifestPath string `fig:"manifestPath"`
+		// ReloadIntervalMs is how often the manifest file is checked for changes. Zero uses the default of 5000.
+		ReloadIntervalMs int `fig:"reloadIntervalMs"`
 	}
 
 	ServerConfigRest struct {
@@ -79,4 +108,65 @@ type (
 		TableNames      []string          `fig:"tableNames"`
 		TopicToTableMap map[string]string `fig:"topicToTableMap"`
 	}
+
+	InventoryConfig struct {
+		ReservationTTLSecond            int `fig:"reservationTTLSecond"`
+		ReservationExpiryIntervalSecond int `fig:"reservationExpiryIntervalSecond"`
+		ReservationExpiryBatchSize      int `fig:"reservationExpiryBatchSize"`
+	}
+
+	ProductConfig struct {
+		PriceScheduleIntervalSecond int `fig:"priceScheduleIntervalSecond"`
+		PriceScheduleBatchSize      int `fig:"priceScheduleBatchSize"`
+		MediaMaxSizeMB              int `fig:"mediaMaxSizeMB"`
+		MediaURLTTLSecond           int `fig:"mediaURLTTLSecond"`
+		MediaCleanupIntervalSecond  int `fig:"mediaCleanupIntervalSecond"`
+		MediaCleanupBatchSize       int `fig:"mediaCleanupBatchSize"`
+	}
+
+	StorageConfig struct {
+		// Driver is either local or s3.
+		Driver string             `fig:"driver"`
+		Local  StorageLocalConfig `fig:"local"`
+		S3     StorageS3Config    `fig:"s3"`
+	}
+	StorageLocalConfig struct {
+		Root       string `fig:"root"`
+		BaseUrl    string `fig:"baseUrl"`
+		SigningKey string `fig:"signingKey"`
+	}
+	StorageS3Config struct {
+		Endpoint        string `fig:"endpoint"`
+		Region          string `fig:"region"`
+		Bucket          string `fig:"bucket"`
+		AccessKeyID     string `fig:"accessKeyID"`
+		SecretAccessKey string `fig:"secretAccessKey"`
+		UseSSL          bool   `fig:"useSSL"`
+	}
+
+	AuthConfig struct {
+		JWT     AuthJWTConfig      `fig:"jwt"`
+		APIKeys []AuthAPIKeyConfig `fig:"apiKeys"`
+	}
+	AuthJWTConfig struct {
+		// JWKSPath is a local JWKS file of the keys tokens are signed with. It is used instead of JWKSURL when set.
+		JWKSPath string `fig:"jwksPath"`
+		// JWKSURL is the JWKS endpoint of the identity provider. JWTs are not accepted when neither is set.
+		JWKSURL string `fig:"jwksUrl"`
+		// JWKSRefreshIntervalMs is how often the keys are loaded again. Zero uses the default of 300000.
+		JWKSRefreshIntervalMs int    `fig:"jwksRefreshIntervalMs"`
+		Issuer                string `fig:"issuer"`
+		Audience              string `fig:"audience"`
+		// TenantClaim is the claim holding the tenant of the subject. Empty uses tenant_id.
+		TenantClaim string `fig:"tenantClaim"`
+		// RolesClaim is the claim holding the roles of the subject. Empty uses roles.
+		RolesClaim string `fig:"rolesClaim"`
+	}
+	AuthAPIKeyConfig struct {
+		Key     string   `fig:"key"`
+		Subject string   `fig:"subject"`
+		Tenant  string   `fig:"tenant"`
+		Roles   []string `fig:"roles"`
+		Scopes  []string `fig:"scopes"`
+	}
 )
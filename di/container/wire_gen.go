@@ -9,15 +9,23 @@ package container
 import (
 	"gobase/di/provider"
 	"gobase/di/registry"
-	"gobase/internal/domain/product/dataloader"
+	"gobase/internal/domain/category/repository"
+	"gobase/internal/domain/category/resolver"
+	"gobase/internal/domain/category/usecase"
+	"gobase/internal/domain/inventory/event/publisher"
+	"gobase/internal/domain/inventory/repository"
+	"gobase/internal/domain/inventory/resolver"
+	"gobase/internal/domain/inventory/usecase"
 	"gobase/internal/domain/product/event/publisher"
 	"gobase/internal/domain/product/event/subscriber"
+	"gobase/internal/domain/product/handler"
 	"gobase/internal/domain/product/repository"
 	"gobase/internal/domain/product/resolver"
 	"gobase/internal/domain/product/usecase"
 	"gobase/internal/pkg/middleware/graphql"
 	"gobase/transport/graphql"
 	"gobase/transport/rest"
+	"gobase/transport/scheduler"
 	"gobase/transport/watermill"
 )
 
@@ -27,62 +35,141 @@ func InitializeApplication(appContext registry.ApplicationContext) (*registry.Ap
 	agentListen := appContext.AgentListen
 	readConfig := appContext.ReadConfig
 	mainConfig := provider.ProvideConfig(appContext, agentListen, readConfig)
-	restRouter := &registry.RESTRouter{}
-	iApplicationTransportREST, cleanup := transportrest.NewTransport(mainConfig, restRouter)
 	db := provider.ProvideInfrastructureBun(mainConfig)
 	repositoryOpts := productrepository.RepositoryOpts{
 		Bun: db,
 	}
 	repository := productrepository.NewRepository(repositoryOpts)
+	categoryrepositoryRepositoryOpts := categoryrepository.RepositoryOpts{
+		Bun: db,
+	}
+	categoryrepositoryRepository := categoryrepository.NewRepository(categoryrepositoryRepositoryOpts)
 	localizer := provider.ProvideInfrastructureLocalizer()
 	structProcessorService := provider.ProvideServiceStructProcessorService(localizer)
+	excel := provider.ProvideInfrastructureExcelManager()
 	loggerAdapter := provider.ProvideWatermillLogger()
 	publisher, err := provider.ProvideWatermillPublisher(loggerAdapter)
 	if err != nil {
-		cleanup()
 		return nil, nil, err
 	}
 	subscriber, err := provider.ProvideWatermillSubscriber(publisher, loggerAdapter)
 	if err != nil {
-		cleanup()
 		return nil, nil, err
 	}
 	service, err := provider.ProvideWatermillService(mainConfig, loggerAdapter, db, publisher, subscriber)
 	if err != nil {
-		cleanup()
 		return nil, nil, err
 	}
 	eventOpts := producteventpublisher.EventOpts{
 		Watermillsvc: service,
 	}
 	event := producteventpublisher.NewEvent(eventOpts)
+	storage, err := provider.ProvideInfrastructureBlobStorage(mainConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	broker, cleanup := provider.ProvideServiceProductEventBroker()
+	relay, cleanup2 := provider.ProvideServiceProductEventRelay(mainConfig, db, broker)
 	useCaseOpts := productusecase.UseCaseOpts{
+		Config:                mainConfig,
 		Bun:                   db,
 		Repository:            repository,
+		CategoryRepository:    categoryrepositoryRepository,
 		SP:                    structProcessorService,
+		Localizer:             localizer,
+		Excel:                 excel,
 		ProductEventPublisher: event,
+		Storage:               storage,
+		ProductEvents:         broker,
+		ProductEventRelay:     relay,
 	}
 	useCase := productusecase.NewUseCase(useCaseOpts)
+	handlerOptions := producthandler.HandlerOptions{
+		ProductUseCase: useCase,
+	}
+	handler := producthandler.NewHandler(handlerOptions)
+	restRouter := &registry.RESTRouter{
+		Product:     handler,
+		BlobStorage: storage,
+	}
+	authenticator, cleanup3, err := provider.ProvideServiceAuthenticator(mainConfig)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	iApplicationTransportREST, cleanup4 := transportrest.NewTransport(mainConfig, restRouter, authenticator)
 	resolverOptions := productresolver.ResolverOptions{
 		ProductUseCase: useCase,
 	}
 	resolver := productresolver.NewResolver(resolverOptions)
-	graphQLResolver := registry.GraphQLResolver{
-		Product: resolver,
+	categoryusecaseUseCaseOpts := categoryusecase.UseCaseOpts{
+		Bun:               db,
+		Repository:        categoryrepositoryRepository,
+		ProductRepository: repository,
+		SP:                structProcessorService,
+		Localizer:         localizer,
 	}
-	dataloader := productloader.NewDataloader(repository)
-	graphQLDataloader := registry.GraphQLDataloader{
-		Product: dataloader,
+	categoryusecaseUseCase := categoryusecase.NewUseCase(categoryusecaseUseCaseOpts)
+	categoryresolverResolverOptions := categoryresolver.ResolverOptions{
+		CategoryUseCase: categoryusecaseUseCase,
 	}
-	v := middlewaregraphql.NewDataloader(graphQLDataloader)
+	categoryresolverResolver := categoryresolver.NewResolver(categoryresolverResolverOptions)
+	inventoryrepositoryRepositoryOpts := inventoryrepository.RepositoryOpts{
+		Bun: db,
+	}
+	inventoryrepositoryRepository := inventoryrepository.NewRepository(inventoryrepositoryRepositoryOpts)
+	inventoryeventpublisherEventOpts := inventoryeventpublisher.EventOpts{
+		Watermillsvc: service,
+	}
+	inventoryeventpublisherEvent := inventoryeventpublisher.NewEvent(inventoryeventpublisherEventOpts)
+	inventoryusecaseUseCaseOpts := inventoryusecase.UseCaseOpts{
+		Bun:                     db,
+		Config:                  mainConfig,
+		Repository:              inventoryrepositoryRepository,
+		ProductRepository:       repository,
+		SP:                      structProcessorService,
+		Localizer:               localizer,
+		InventoryEventPublisher: inventoryeventpublisherEvent,
+	}
+	inventoryusecaseUseCase := inventoryusecase.NewUseCase(inventoryusecaseUseCaseOpts)
+	inventoryresolverResolverOptions := inventoryresolver.ResolverOptions{
+		InventoryUseCase: inventoryusecaseUseCase,
+	}
+	inventoryresolverResolver := inventoryresolver.NewResolver(inventoryresolverResolverOptions)
+	graphQLResolver := registry.GraphQLResolver{
+		Product:   resolver,
+		Category:  categoryresolverResolver,
+		Inventory: inventoryresolverResolver,
+	}
+	graphQLDataloaderFactory := provider.ProvideDataloaderGraphQLFactory(mainConfig, repository, categoryrepositoryRepository, inventoryrepositoryRepository)
+	v := middlewaregraphql.NewDataloader(graphQLDataloaderFactory)
 	v2 := middlewaregraphql.NewOtel()
+	v3 := middlewaregraphql.NewLanguage()
+	limit := middlewaregraphql.NewLimit(mainConfig)
+	authentication := middlewaregraphql.NewAuthentication(authenticator)
+	v4 := middlewaregraphql.NewWebsocketInit(authenticator)
+	trustedDocuments, cleanup5, err := middlewaregraphql.NewTrustedDocuments(mainConfig)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	transportOpts := transportgraphql.TransportOpts{
 		GraphQLResolver:      graphQLResolver,
 		MiddlewareDataloader: v,
 		MiddlewareOtel:       v2,
+		MiddlewareLanguage:   v3,
+		MiddlewareLimit:      limit,
+		MiddlewareAuth:       authentication,
+		WebsocketInit:        v4,
+		TrustedDocuments:     trustedDocuments,
+		Localizer:            localizer,
 		Config:               mainConfig,
 	}
-	iApplicationTransportGraphQL, cleanup2 := transportgraphql.NewTransport(transportOpts)
+	iApplicationTransportGraphQL, cleanup6 := transportgraphql.NewTransport(transportOpts)
 	producteventsubscriberEventOpts := producteventsubscriber.EventOpts{
 		Watermillsvc:   service,
 		ProductUseCase: useCase,
@@ -92,11 +179,22 @@ func InitializeApplication(appContext registry.ApplicationContext) (*registry.Ap
 		WatermillService:       service,
 		ProductEventSubscriber: producteventsubscriberEvent,
 	}
-	iApplicationTransportWatermill, cleanup3 := transportwatermill.NewTransport(transportwatermillTransportOpts)
-	otelsvcService, cleanup4 := provider.ProvideServiceOtelService(mainConfig)
-	v3 := provider.Initializer(mainConfig, otelsvcService)
-	application := registry.NewApplication(iApplicationTransportREST, iApplicationTransportGraphQL, iApplicationTransportWatermill, v3)
+	iApplicationTransportWatermill, cleanup7 := transportwatermill.NewTransport(transportwatermillTransportOpts)
+	transportschedulerTransportOpts := transportscheduler.TransportOpts{
+		Config:           mainConfig,
+		InventoryUseCase: inventoryusecaseUseCase,
+		ProductUseCase:   useCase,
+	}
+	iApplicationTransportScheduler, cleanup8 := transportscheduler.NewTransport(transportschedulerTransportOpts)
+	otelsvcService, cleanup9 := provider.ProvideServiceOtelService(mainConfig)
+	v5 := provider.Initializer(mainConfig, otelsvcService)
+	application := registry.NewApplication(iApplicationTransportREST, iApplicationTransportGraphQL, iApplicationTransportWatermill, iApplicationTransportScheduler, v5)
 	return application, func() {
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
 		cleanup4()
 		cleanup3()
 		cleanup2()
@@ -1,14 +1,41 @@
 package provider
 
 import (
+	"time"
+
 	"github.com/google/wire"
 
+	"gobase/config"
 	"gobase/di/registry"
+	categoryloader "gobase/internal/domain/category/dataloader"
+	categoryrepository "gobase/internal/domain/category/repository"
+	inventoryloader "gobase/internal/domain/inventory/dataloader"
+	inventoryrepository "gobase/internal/domain/inventory/repository"
 	productloader "gobase/internal/domain/product/dataloader"
+	productrepository "gobase/internal/domain/product/repository"
+	"gobase/internal/pkg/service/gqldataloader"
 )
 
 var DataloaderGraphQLSet = wire.NewSet(
-	productloader.NewDataloader,
-
-	wire.Struct(new(registry.GraphQLDataloader), "*"),
+	ProvideDataloaderGraphQLFactory,
 )
+
+func ProvideDataloaderGraphQLFactory(
+	cfg *config.MainConfig,
+	productRepository productrepository.Repository,
+	categoryRepository categoryrepository.Repository,
+	inventoryRepository inventoryrepository.Repository,
+) registry.GraphQLDataloaderFactory {
+	opts := gqldataloader.Options{
+		Wait:          time.Duration(cfg.Server.GraphQL.DataloaderWaitMs) * time.Millisecond,
+		BatchCapacity: cfg.Server.GraphQL.DataloaderBatchCapacity,
+	}
+
+	return func() registry.GraphQLDataloader {
+		return registry.GraphQLDataloader{
+			Product:   productloader.NewDataloader(productRepository, opts),
+			Category:  categoryloader.NewDataloader(categoryRepository, opts),
+			Inventory: inventoryloader.NewDataloader(inventoryRepository, opts),
+		}
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"github.com/google/wire"
 
+	inventoryeventpublisher "gobase/internal/domain/inventory/event/publisher"
 	producteventpublisher "gobase/internal/domain/product/event/publisher"
 	producteventsubscriber "gobase/internal/domain/product/event/subscriber"
 )
@@ -12,4 +13,6 @@ var EventSet = wire.NewSet(
 	producteventsubscriber.NewEvent,
 	wire.Struct(new(producteventpublisher.EventOpts), "*"),
 	producteventpublisher.NewEvent,
+	wire.Struct(new(inventoryeventpublisher.EventOpts), "*"),
+	inventoryeventpublisher.NewEvent,
 )
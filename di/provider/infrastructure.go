@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"runtime"
 
@@ -21,6 +22,7 @@ import (
 	masterdataentity "gobase/internal/db/masterdata/entity"
 	"gobase/internal/pkg/helper/excel"
 	"gobase/internal/pkg/helper/excel/excelize"
+	"gobase/internal/pkg/service/blobsvc"
 
 	_ "github.com/lib/pq" // used for sql queries
 )
@@ -29,6 +31,7 @@ var InfrastructureSet = wire.NewSet(
 	ProvideInfrastructureLocalizer,
 	ProvideInfrastructureBun,
 	ProvideInfrastructureExcelManager,
+	ProvideInfrastructureBlobStorage,
 )
 
 func ProvideInfrastructureLocalizer() localization.Localizer {
@@ -74,12 +77,52 @@ func ProvideInfrastructureBun(cfg *config.MainConfig) *bun.DB {
 	if env.GetEnvironmentName() == "local" {
 		db.NewDropTable().Model(&masterdataentity.Product{}).Exec(context.Background())
 		db.NewCreateTable().Model(&masterdataentity.Product{}).Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.Product{}).Index("product_status_idx").Column("status").Exec(context.Background())
 		db.NewDropTable().Model(&masterdataentity.ProductVariant{}).Exec(context.Background())
 		db.NewCreateTable().Model(&masterdataentity.ProductVariant{}).Exec(context.Background())
 		db.NewDropTable().Model(&masterdataentity.ProductAttribute{}).Exec(context.Background())
 		db.NewCreateTable().Model(&masterdataentity.ProductAttribute{}).Exec(context.Background())
 		db.NewDropTable().Model(&masterdataentity.RelProductVariantProductAttribute{}).Exec(context.Background())
 		db.NewCreateTable().Model(&masterdataentity.RelProductVariantProductAttribute{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.Category{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.Category{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.CategoryClosure{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.CategoryClosure{}).Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.CategoryClosure{}).Index("category_closure_descendant_id_idx").Column("descendant_id").Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.RelProductCategory{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.RelProductCategory{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.InventoryLocation{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.InventoryLocation{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.InventoryStock{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.InventoryStock{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.InventoryMovement{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.InventoryMovement{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.InventoryReservation{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.InventoryReservation{}).Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.InventoryReservation{}).Index("inventory_reservation_status_expires_at_idx").Column("status", "expires_at").Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductVariantPrice{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductVariantPrice{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductVariantPriceHistory{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductVariantPriceHistory{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductTranslation{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductTranslation{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductSearch{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductSearch{}).Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.ProductSearch{}).Index("product_search_document_idx").Using("GIN").Column("document").Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.ProductVariantPriceHistory{}).Index("product_variant_price_history_variant_valid_from_idx").Column("product_variant_id", "valid_from").Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductBundle{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductBundle{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductBundleComponent{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductBundleComponent{}).Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.ProductBundleComponent{}).Index("product_bundle_component_variant_id_idx").Column("product_variant_id").Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductMedia{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductMedia{}).Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.ProductMedia{}).Index("product_media_product_position_idx").Column("product_id", "position").Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.ProductTag{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.ProductTag{}).Exec(context.Background())
+		db.NewDropTable().Model(&masterdataentity.RelProductTag{}).Exec(context.Background())
+		db.NewCreateTable().Model(&masterdataentity.RelProductTag{}).Exec(context.Background())
+		db.NewCreateIndex().Model(&masterdataentity.RelProductTag{}).Index("rel_product_tag_tag_id_idx").Column("product_tag_id").Exec(context.Background())
 	}
 
 	return db
@@ -88,3 +131,33 @@ func ProvideInfrastructureBun(cfg *config.MainConfig) *bun.DB {
 func ProvideInfrastructureExcelManager() excel.Excel {
 	return excelize.NewExcel()
 }
+
+// ProvideInfrastructureBlobStorage creates the storage of the media. The local storage without a signing key, which
+// is what a config predating the storage section gives, is disabled with a warning instead of failing the startup.
+func ProvideInfrastructureBlobStorage(cfg *config.MainConfig) (blobsvc.Storage, error) {
+	storage, err := blobsvc.NewStorage(blobsvc.StorageOpts{
+		Driver: blobsvc.Driver(cfg.Storage.Driver),
+		Local: blobsvc.LocalStorageOpts{
+			Root:       cfg.Storage.Local.Root,
+			BaseURL:    cfg.Storage.Local.BaseUrl,
+			SigningKey: cfg.Storage.Local.SigningKey,
+		},
+		S3: blobsvc.S3StorageOpts{
+			Endpoint:        cfg.Storage.S3.Endpoint,
+			Region:          cfg.Storage.S3.Region,
+			Bucket:          cfg.Storage.S3.Bucket,
+			AccessKeyID:     cfg.Storage.S3.AccessKeyID,
+			SecretAccessKey: cfg.Storage.S3.SecretAccessKey,
+			UseSSL:          cfg.Storage.S3.UseSSL,
+		},
+	})
+	if errors.Is(err, blobsvc.ErrNoSigningKey) {
+		log.Warn().Msg("blob storage is disabled, set storage.local.signingKey or storage.driver to store product media")
+		return blobsvc.NewDisabledStorage(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return storage, nil
+}
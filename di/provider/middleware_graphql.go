@@ -9,4 +9,9 @@ import (
 var MiddlewareGraphQLSet = wire.NewSet(
 	middlewaregraphql.NewDataloader,
 	middlewaregraphql.NewOtel,
+	middlewaregraphql.NewLanguage,
+	middlewaregraphql.NewLimit,
+	middlewaregraphql.NewWebsocketInit,
+	middlewaregraphql.NewTrustedDocuments,
+	middlewaregraphql.NewAuthentication,
 )
@@ -3,10 +3,18 @@ package provider
 import (
 	"github.com/google/wire"
 
+	categoryrepository "gobase/internal/domain/category/repository"
+	inventoryrepository "gobase/internal/domain/inventory/repository"
 	productrepository "gobase/internal/domain/product/repository"
 )
 
 var RepositorySet = wire.NewSet(
 	wire.Struct(new(productrepository.RepositoryOpts), "*"),
 	productrepository.NewRepository,
+
+	wire.Struct(new(categoryrepository.RepositoryOpts), "*"),
+	categoryrepository.NewRepository,
+
+	wire.Struct(new(inventoryrepository.RepositoryOpts), "*"),
+	inventoryrepository.NewRepository,
 )
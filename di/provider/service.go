@@ -5,16 +5,29 @@ import (
 
 	"clodeo.tech/public/go-universe/pkg/localization"
 	"github.com/google/wire"
+	"github.com/uptrace/bun"
 
 	"gobase/config"
 	"gobase/di/registry"
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/service/authsvc"
+	"gobase/internal/pkg/service/broadcastsvc"
 	"gobase/internal/pkg/service/otelsvc"
 	"gobase/internal/pkg/service/structprocessor"
 )
 
+// productEventBufferSize is the number of product events a GraphQL subscription can fall behind before it misses some.
+const productEventBufferSize = 16
+
+// productEventChannel is the notification channel relaying the product events to every instance.
+const productEventChannel = "product_events"
+
 var ServiceSet = wire.NewSet(
 	ProvideServiceStructProcessorService,
 	ProvideServiceOtelService,
+	ProvideServiceProductEventBroker,
+	ProvideServiceProductEventRelay,
+	ProvideServiceAuthenticator,
 )
 
 func ProvideServiceStructProcessorService(localizer localization.Localizer) structprocessor.StructProcessorService {
@@ -38,3 +51,42 @@ func ProvideServiceOtelService(cfg *config.MainConfig) (otelsvc.Service, registr
 
 	return svc, svc.Shutdown
 }
+
+// ProvideServiceProductEventBroker creates the broker fanning product events out to the GraphQL subscriptions.
+// Its cleanup ends the subscriptions still open at shutdown.
+func ProvideServiceProductEventBroker() (*broadcastsvc.Broker[*productdto.ProductEvent], registry.CleanupFunc) {
+	broker := broadcastsvc.NewBroker[*productdto.ProductEvent](productEventBufferSize)
+
+	return broker, broker.Close
+}
+
+// ProvideServiceProductEventRelay creates the relay feeding the product events consumed by any instance to the broker
+// of this one. Its cleanup stops listening.
+func ProvideServiceProductEventRelay(cfg *config.MainConfig, db *bun.DB, broker *broadcastsvc.Broker[*productdto.ProductEvent]) (*broadcastsvc.Relay[*productdto.ProductEvent], registry.CleanupFunc) {
+	relay := broadcastsvc.NewRelay(broadcastsvc.RelayOpts[*productdto.ProductEvent]{
+		DB:      db,
+		DSN:     cfg.Rdbms.App.DSN,
+		Channel: productEventChannel,
+		Broker:  broker,
+	})
+
+	return relay, relay.Close
+}
+
+// ProvideServiceAuthenticator creates the authenticator of the transports, trying the static API keys and then the
+// JWTs of the auth config. JWTs are only accepted when a JWKS is configured.
+func ProvideServiceAuthenticator(cfg *config.MainConfig) (authsvc.Authenticator, registry.CleanupFunc, error) {
+	chain := authsvc.Chain{authsvc.NewAPIKeyAuthenticator(cfg.Auth.APIKeys)}
+
+	jwtCfg := cfg.Auth.JWT
+	if jwtCfg.JWKSPath == "" && jwtCfg.JWKSURL == "" {
+		return chain, func() {}, nil
+	}
+
+	jwtAuthenticator, err := authsvc.NewJWTAuthenticator(jwtCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(chain, jwtAuthenticator), jwtAuthenticator.Close, nil
+}
@@ -8,4 +8,5 @@ var TransportSet = wire.NewSet(
 	TransportRESTSet,
 	TransportGraphQLSet,
 	TransportWatermillSet,
+	TransportSchedulerSet,
 )
@@ -4,6 +4,8 @@ import (
 	"github.com/google/wire"
 
 	"gobase/di/registry"
+	categoryresolver "gobase/internal/domain/category/resolver"
+	inventoryresolver "gobase/internal/domain/inventory/resolver"
 	productresolver "gobase/internal/domain/product/resolver"
 	transportgraphql "gobase/transport/graphql"
 )
@@ -12,6 +14,12 @@ var TransportGraphQLDependencySet = wire.NewSet(
 	wire.Struct(new(productresolver.ResolverOptions), "*"),
 	productresolver.NewResolver,
 
+	wire.Struct(new(categoryresolver.ResolverOptions), "*"),
+	categoryresolver.NewResolver,
+
+	wire.Struct(new(inventoryresolver.ResolverOptions), "*"),
+	inventoryresolver.NewResolver,
+
 	wire.Struct(new(registry.GraphQLResolver), "*"),
 )
 
@@ -4,10 +4,14 @@ import (
 	"github.com/google/wire"
 
 	"gobase/di/registry"
+	producthandler "gobase/internal/domain/product/handler"
 	transportrest "gobase/transport/rest"
 )
 
 var TransportRESTDependencySet = wire.NewSet(
+	wire.Struct(new(producthandler.HandlerOptions), "*"),
+	producthandler.NewHandler,
+
 	wire.Struct(new(registry.RESTRouter), "*"),
 )
 
@@ -0,0 +1,12 @@
+package provider
+
+import (
+	"github.com/google/wire"
+
+	transportscheduler "gobase/transport/scheduler"
+)
+
+var TransportSchedulerSet = wire.NewSet(
+	wire.Struct(new(transportscheduler.TransportOpts), "*"),
+	transportscheduler.NewTransport,
+)
@@ -3,10 +3,18 @@ package provider
 import (
 	"github.com/google/wire"
 
+	categoryusecase "gobase/internal/domain/category/usecase"
+	inventoryusecase "gobase/internal/domain/inventory/usecase"
 	productusecase "gobase/internal/domain/product/usecase"
 )
 
 var UseCaseSet = wire.NewSet(
 	wire.Struct(new(productusecase.UseCaseOpts), "*"),
 	productusecase.NewUseCase,
+
+	wire.Struct(new(categoryusecase.UseCaseOpts), "*"),
+	categoryusecase.NewUseCase,
+
+	wire.Struct(new(inventoryusecase.UseCaseOpts), "*"),
+	inventoryusecase.NewUseCase,
 )
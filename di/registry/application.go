@@ -13,6 +13,7 @@ type Application struct {
 	TransportREST      IApplicationTransportREST
 	TransportGraphQL   IApplicationTransportGraphQL
 	TransportWatermill IApplicationTransportWatermill
+	TransportScheduler IApplicationTransportScheduler
 	Initialize         InitializerFunc
 
 	terminationSignal chan os.Signal
@@ -22,12 +23,14 @@ func NewApplication(
 	transportREST IApplicationTransportREST,
 	transportGraphQL IApplicationTransportGraphQL,
 	transportWatermill IApplicationTransportWatermill,
+	transportScheduler IApplicationTransportScheduler,
 	initializer InitializerFunc,
 ) *Application {
 	return &Application{
 		TransportREST:      transportREST,
 		TransportGraphQL:   transportGraphQL,
 		TransportWatermill: transportWatermill,
+		TransportScheduler: transportScheduler,
 		Initialize:         initializer,
 	}
 }
@@ -61,6 +64,16 @@ func (a *Application) RunWatermill(ctx context.Context) {
 	}()
 }
 
+func (a *Application) RunScheduler(ctx context.Context) {
+	go func() {
+		log.Info().Msg("starting scheduler")
+		err := a.TransportScheduler.Run(ctx)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to run scheduler")
+		}
+	}()
+}
+
 func (a *Application) Run(cleanup CleanupFunc) {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -70,6 +83,7 @@ func (a *Application) Run(cleanup CleanupFunc) {
 	a.RunTransportREST(ctx)
 	a.RunTransportGraphQL(ctx)
 	a.RunWatermill(ctx)
+	a.RunScheduler(ctx)
 
 	<-ctx.Done()
 
@@ -5,8 +5,14 @@ import (
 
 	"github.com/google/gops/agent"
 
+	categoryloader "gobase/internal/domain/category/dataloader"
+	categoryresolver "gobase/internal/domain/category/resolver"
+	inventoryloader "gobase/internal/domain/inventory/dataloader"
+	inventoryresolver "gobase/internal/domain/inventory/resolver"
 	productloader "gobase/internal/domain/product/dataloader"
+	producthandler "gobase/internal/domain/product/handler"
 	productresolver "gobase/internal/domain/product/resolver"
+	"gobase/internal/pkg/service/blobsvc"
 )
 
 type CleanupFunc = func()
@@ -24,6 +30,9 @@ type IApplicationTransportGraphQL interface {
 type IApplicationTransportWatermill interface {
 	Run(ctx context.Context) error
 }
+type IApplicationTransportScheduler interface {
+	Run(ctx context.Context) error
+}
 
 type ApplicationContext struct {
 	ConfigPath  string
@@ -33,15 +42,24 @@ type ApplicationContext struct {
 }
 
 type RESTRouter struct {
+	Product     producthandler.Handler
+	BlobStorage blobsvc.Storage
 }
 
 type GraphQLResolver struct {
-	Product productresolver.Resolver
+	Product   productresolver.Resolver
+	Category  categoryresolver.Resolver
+	Inventory inventoryresolver.Resolver
 }
 
 type GraphQLMiddleware struct {
 }
 
 type GraphQLDataloader struct {
-	Product *productloader.Dataloader
+	Product   *productloader.Dataloader
+	Category  *categoryloader.Dataloader
+	Inventory *inventoryloader.Dataloader
 }
+
+// GraphQLDataloaderFactory creates a fresh set of dataloaders, so that their caches live no longer than a request.
+type GraphQLDataloaderFactory func() GraphQLDataloader
@@ -0,0 +1,80 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	graphqlgen "gobase/graphql/generated"
+	categorydto "gobase/internal/domain/category/dto"
+	productdto "gobase/internal/domain/product/dto"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+)
+
+// Parent is the resolver for the parent field.
+func (r *categoryResolver) Parent(ctx context.Context, obj *categorydto.Category) (*categorydto.Category, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Category.Category.Load(ctx, *obj.ParentID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	if len(dtos) == 0 {
+		return nil, nil
+	}
+	return dtos[0], nil
+}
+
+// Children is the resolver for the children field.
+func (r *categoryResolver) Children(ctx context.Context, obj *categorydto.Category) ([]*categorydto.Category, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Category.Children.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// Ancestors is the resolver for the ancestors field.
+func (r *categoryResolver) Ancestors(ctx context.Context, obj *categorydto.Category) ([]*categorydto.Category, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Category.Ancestors.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// Categories is the resolver for the categories field.
+func (r *productResolver) Categories(ctx context.Context, obj *productdto.Product) ([]*categorydto.Category, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Category.ProductCategory.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// Category returns graphqlgen.CategoryResolver implementation.
+func (r *Resolver) Category() graphqlgen.CategoryResolver { return &categoryResolver{r} }
+
+type categoryResolver struct{ *Resolver }
@@ -0,0 +1,42 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	categorydto "gobase/internal/domain/category/dto"
+
+	"github.com/google/uuid"
+)
+
+// CreateCategory is the resolver for the createCategory field.
+func (r *mutationResolver) CreateCategory(ctx context.Context, input categorydto.CreateCategoryInput) (*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.Create(ctx, input)
+}
+
+// UpdateCategory is the resolver for the updateCategory field.
+func (r *mutationResolver) UpdateCategory(ctx context.Context, input categorydto.UpdateCategoryInput) (*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.Update(ctx, input)
+}
+
+// MoveCategory is the resolver for the moveCategory field.
+func (r *mutationResolver) MoveCategory(ctx context.Context, input categorydto.MoveCategoryInput) (*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.Move(ctx, input)
+}
+
+// DeleteCategory is the resolver for the deleteCategory field.
+func (r *mutationResolver) DeleteCategory(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.GraphQLResolver.Category.Delete(ctx, id)
+}
+
+// AssignProductsToCategory is the resolver for the assignProductsToCategory field.
+func (r *mutationResolver) AssignProductsToCategory(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.AssignProducts(ctx, input)
+}
+
+// UnassignProductsFromCategory is the resolver for the unassignProductsFromCategory field.
+func (r *mutationResolver) UnassignProductsFromCategory(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.UnassignProducts(ctx, input)
+}
@@ -0,0 +1,33 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	categorydto "gobase/internal/domain/category/dto"
+	"gobase/internal/pkg/service/crud"
+
+	"github.com/google/uuid"
+)
+
+// Category is the resolver for the category field.
+func (r *queryResolver) Category(ctx context.Context, id uuid.UUID) (*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.FindById(ctx, id)
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context, qop *categorydto.CategoryQop) (*crud.PageResult[*categorydto.Category], error) {
+	return r.GraphQLResolver.Category.FindAll(ctx, qop)
+}
+
+// CategoryDescendants is the resolver for the categoryDescendants field.
+func (r *queryResolver) CategoryDescendants(ctx context.Context, id uuid.UUID, maxDepth *int) ([]*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.FindDescendants(ctx, id, maxDepth)
+}
+
+// CategoryBreadcrumbs is the resolver for the categoryBreadcrumbs field.
+func (r *queryResolver) CategoryBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*categorydto.Category, error) {
+	return r.GraphQLResolver.Category.FindBreadcrumbs(ctx, id)
+}
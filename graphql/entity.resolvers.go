@@ -0,0 +1,34 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	graphqlgen "gobase/graphql/generated"
+	graphqlgenmodel "gobase/graphql/generated/model"
+	productdto "gobase/internal/domain/product/dto"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// FindManyProductByIDs is the resolver for the findManyProductByIDs field.
+func (r *entityResolver) FindManyProductByIDs(ctx context.Context, reps []*graphqlgenmodel.ProductByIDsInput) ([]*productdto.Product, error) {
+	return r.GraphQLResolver.Product.FindByIds(ctx, lo.Map(reps, func(rep *graphqlgenmodel.ProductByIDsInput, _ int) uuid.UUID {
+		return rep.ID
+	}))
+}
+
+// FindManyProductVariantByIDs is the resolver for the findManyProductVariantByIDs field.
+func (r *entityResolver) FindManyProductVariantByIDs(ctx context.Context, reps []*graphqlgenmodel.ProductVariantByIDsInput) ([]*productdto.ProductVariant, error) {
+	return r.GraphQLResolver.Product.FindVariantsByIds(ctx, lo.Map(reps, func(rep *graphqlgenmodel.ProductVariantByIDsInput, _ int) uuid.UUID {
+		return rep.ID
+	}))
+}
+
+// Entity returns graphqlgen.EntityResolver implementation.
+func (r *Resolver) Entity() graphqlgen.EntityResolver { return &entityResolver{r} }
+
+type entityResolver struct{ *Resolver }
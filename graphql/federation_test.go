@@ -0,0 +1,134 @@
+package graphql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"gobase/graphql"
+	graphqlgen "gobase/graphql/generated"
+)
+
+const federationLinkURL = "https://specs.apollo.dev/federation/v2"
+
+// federationDefinitions are the definitions of the federation directives the gateway adds to the SDL of a subgraph.
+const federationDefinitions = `
+directive @link(url: String!, import: [String!]) repeatable on SCHEMA
+directive @key(fields: FieldSet!, resolvable: Boolean = true) repeatable on OBJECT | INTERFACE
+directive @shareable repeatable on FIELD_DEFINITION | OBJECT
+directive @external on OBJECT | FIELD_DEFINITION
+directive @requires(fields: FieldSet!) on FIELD_DEFINITION
+directive @provides(fields: FieldSet!) on FIELD_DEFINITION
+directive @override(from: String!, label: String) on FIELD_DEFINITION
+directive @inaccessible on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION
+scalar FieldSet
+`
+
+var federationDirectives = []string{"key", "shareable", "external", "requires", "provides", "override", "inaccessible"}
+
+func TestFederationServiceSDLComposes(t *testing.T) {
+	sdl := serviceSDL(t)
+
+	schema, err := gqlparser.LoadSchema(
+		&ast.Source{Name: "federation", Input: federationDefinitions, BuiltIn: true},
+		&ast.Source{Name: "_service", Input: sdl},
+	)
+	if err != nil {
+		t.Fatalf("the SDL of the service is not a valid schema: %v", err)
+	}
+
+	imports := federationImports(t, schema)
+
+	for _, typeName := range []string{"_Service", "_Entity", "_Any"} {
+		if schema.Types[typeName] != nil {
+			t.Errorf("the SDL defines %s, which the gateway adds itself", typeName)
+		}
+	}
+
+	for _, def := range schema.Types {
+		if def.BuiltIn {
+			continue
+		}
+		checkImported(t, imports, def.Name, def.Directives)
+		for _, field := range def.Fields {
+			checkImported(t, imports, def.Name+"."+field.Name, field.Directives)
+		}
+
+		for _, key := range def.Directives.ForNames("key") {
+			for _, fieldName := range strings.Fields(key.Arguments.ForName("fields").Value.Raw) {
+				if def.Fields.ForName(fieldName) == nil {
+					t.Errorf("the key of %s selects the unknown field %s", def.Name, fieldName)
+				}
+			}
+		}
+	}
+
+	for _, typeName := range []string{"Product", "ProductVariant"} {
+		def := schema.Types[typeName]
+		if def == nil || len(def.Directives.ForNames("key")) == 0 {
+			t.Errorf("%s is not an entity with a key", typeName)
+		}
+	}
+}
+
+// serviceSDL returns the SDL the service reports to the gateway. The _service field does not use the resolvers.
+func serviceSDL(t *testing.T) string {
+	t.Helper()
+
+	srv := handler.New(graphqlgen.NewExecutableSchema(graphqlgen.Config{
+		Resolvers: &graphql.Resolver{},
+	}))
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+
+	var resp struct {
+		Service struct {
+			SDL string `json:"sdl"`
+		} `json:"_service"`
+	}
+	err := client.New(srv).Post(`{ _service { sdl } }`, &resp)
+	if err != nil {
+		t.Fatalf("querying the SDL of the service: %v", err)
+	}
+
+	return resp.Service.SDL
+}
+
+// federationImports returns the federation directives the schema imports with its federation 2 link.
+func federationImports(t *testing.T, schema *ast.Schema) map[string]bool {
+	t.Helper()
+
+	for _, directive := range schema.SchemaDirectives.ForNames("link") {
+		if !strings.HasPrefix(directive.Arguments.ForName("url").Value.Raw, federationLinkURL) {
+			continue
+		}
+
+		imports := map[string]bool{}
+		if arg := directive.Arguments.ForName("import"); arg != nil {
+			for _, child := range arg.Value.Children {
+				imports[strings.TrimPrefix(child.Value.Raw, "@")] = true
+			}
+		}
+		return imports
+	}
+
+	t.Fatalf("the SDL does not link to federation 2 with %s", federationLinkURL)
+	return nil
+}
+
+// checkImported reports the federation directives used on a type or field without being imported by the link.
+func checkImported(t *testing.T, imports map[string]bool, location string, directives ast.DirectiveList) {
+	t.Helper()
+
+	for _, name := range federationDirectives {
+		if directives.ForName(name) != nil && !imports[name] {
+			t.Errorf("%s uses @%s, which is not imported by the federation link", location, name)
+		}
+	}
+}
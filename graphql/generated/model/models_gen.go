@@ -2,5 +2,24 @@
 
 package graphqlgenmodel
 
+import (
+	"github.com/google/uuid"
+)
+
 type Mutation struct {
 }
+
+type ProductByIDsInput struct {
+	ID uuid.UUID `json:"ID"`
+}
+
+type ProductVariantByIDsInput struct {
+	ID uuid.UUID `json:"ID"`
+}
+
+// Subscriptions are served over WebSocket with the graphql-ws and graphql-transport-ws protocols. The connection_init
+// payload authenticates the connection with an apiKey or an Authorization header, and can set its Accept-Language.
+// Products are sent to the subscriptions of every instance, and the products that are not published only to internal
+// callers.
+type Subscription struct {
+}
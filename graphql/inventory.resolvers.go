@@ -0,0 +1,42 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	inventorydto "gobase/internal/domain/inventory/dto"
+	productdto "gobase/internal/domain/product/dto"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+
+	"github.com/samber/lo"
+)
+
+// AvailableStock is the resolver for the availableStock field.
+func (r *productVariantResolver) AvailableStock(ctx context.Context, obj *productdto.ProductVariant) (int, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return 0, err
+	}
+	thunk := loaders.Inventory.AvailableStock.Load(ctx, obj.ID)
+	available, err := thunk()
+	if err != nil {
+		return 0, err
+	}
+	return lo.FromPtr(available), nil
+}
+
+// Stocks is the resolver for the stocks field.
+func (r *productVariantResolver) Stocks(ctx context.Context, obj *productdto.ProductVariant) ([]*inventorydto.InventoryStock, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Inventory.Stock.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
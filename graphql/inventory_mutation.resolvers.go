@@ -0,0 +1,37 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	inventorydto "gobase/internal/domain/inventory/dto"
+
+	"github.com/google/uuid"
+)
+
+// CreateInventoryLocation is the resolver for the createInventoryLocation field.
+func (r *mutationResolver) CreateInventoryLocation(ctx context.Context, input inventorydto.CreateInventoryLocationInput) (*inventorydto.InventoryLocation, error) {
+	return r.GraphQLResolver.Inventory.CreateLocation(ctx, input)
+}
+
+// AdjustStock is the resolver for the adjustStock field.
+func (r *mutationResolver) AdjustStock(ctx context.Context, input inventorydto.AdjustStockInput) (*inventorydto.InventoryStock, error) {
+	return r.GraphQLResolver.Inventory.AdjustStock(ctx, input)
+}
+
+// ReserveStock is the resolver for the reserveStock field.
+func (r *mutationResolver) ReserveStock(ctx context.Context, input inventorydto.ReserveStockInput) (*inventorydto.InventoryReservation, error) {
+	return r.GraphQLResolver.Inventory.ReserveStock(ctx, input)
+}
+
+// ReleaseStockReservation is the resolver for the releaseStockReservation field.
+func (r *mutationResolver) ReleaseStockReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	return r.GraphQLResolver.Inventory.ReleaseReservation(ctx, id)
+}
+
+// CommitStockReservation is the resolver for the commitStockReservation field.
+func (r *mutationResolver) CommitStockReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	return r.GraphQLResolver.Inventory.CommitReservation(ctx, id)
+}
@@ -0,0 +1,33 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	inventorydto "gobase/internal/domain/inventory/dto"
+	"gobase/internal/pkg/service/crud"
+
+	"github.com/google/uuid"
+)
+
+// InventoryLocations is the resolver for the inventoryLocations field.
+func (r *queryResolver) InventoryLocations(ctx context.Context) ([]*inventorydto.InventoryLocation, error) {
+	return r.GraphQLResolver.Inventory.FindLocations(ctx)
+}
+
+// InventoryStocks is the resolver for the inventoryStocks field.
+func (r *queryResolver) InventoryStocks(ctx context.Context, variantID uuid.UUID) ([]*inventorydto.InventoryStock, error) {
+	return r.GraphQLResolver.Inventory.FindStocks(ctx, variantID)
+}
+
+// InventoryMovements is the resolver for the inventoryMovements field.
+func (r *queryResolver) InventoryMovements(ctx context.Context, qop *inventorydto.InventoryMovementQop) (*crud.PageResult[*inventorydto.InventoryMovement], error) {
+	return r.GraphQLResolver.Inventory.FindMovements(ctx, qop)
+}
+
+// InventoryReservation is the resolver for the inventoryReservation field.
+func (r *queryResolver) InventoryReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	return r.GraphQLResolver.Inventory.FindReservationById(ctx, id)
+}
@@ -0,0 +1,32 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/service/crud"
+
+	"github.com/google/uuid"
+)
+
+// Node is the resolver for the node field.
+func (r *queryResolver) Node(ctx context.Context, id string) (crud.Node, error) {
+	typeName, nodeId, err := crud.FromGlobalID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typeName {
+	case productdto.ProductNodeType:
+		productId, err := uuid.Parse(nodeId)
+		if err != nil {
+			return nil, crud.ErrInvalidGlobalID
+		}
+		return r.GraphQLResolver.Product.FindById(ctx, productId, nil)
+	}
+
+	return nil, nil
+}
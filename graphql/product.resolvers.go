@@ -7,13 +7,23 @@ package graphql
 import (
 	"context"
 	graphqlgen "gobase/graphql/generated"
+	productloader "gobase/internal/domain/product/dataloader"
 	productdto "gobase/internal/domain/product/dto"
 	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+	"gobase/internal/pkg/service/crud"
 )
 
 // Variants is the resolver for the variants field.
-func (r *productResolver) Variants(ctx context.Context, obj *productdto.Product) ([]*productdto.ProductVariant, error) {
-	thunk := middlewaregraphql.For(ctx).Product.Variant.Load(ctx, obj.ID)
+func (r *productResolver) Variants(ctx context.Context, obj *productdto.Product, first *int, sort []*crud.Sort, filter *productdto.ProductVariantQopFilter) ([]*productdto.ProductVariant, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := productloader.NewVariantsKey(obj.ID, first, sort, filter)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.Variant.Load(ctx, key)
 	dtos, err := thunk()
 	if err != nil {
 		return nil, err
@@ -23,17 +33,35 @@ func (r *productResolver) Variants(ctx context.Context, obj *productdto.Product)
 
 // Attribute is the resolver for the attribute field.
 func (r *productAttributeValueResolver) Attribute(ctx context.Context, obj *productdto.ProductAttributeValue) (*productdto.ProductAttribute, error) {
-	thunk := middlewaregraphql.For(ctx).Product.Attribute.Load(ctx, obj.AttributeId)
-	dtos, err := thunk()
+	loaders, err := middlewaregraphql.For(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return dtos[0], nil
+	thunk := loaders.Product.Attribute.Load(ctx, obj.AttributeId)
+	return thunk()
 }
 
 // Attributes is the resolver for the attributes field.
 func (r *productVariantResolver) Attributes(ctx context.Context, obj *productdto.ProductVariant) ([]*productdto.ProductAttributeValue, error) {
-	thunk := middlewaregraphql.For(ctx).Product.AttributeValue.Load(ctx, obj.ID)
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.AttributeValue.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// Prices is the resolver for the prices field.
+func (r *productVariantResolver) Prices(ctx context.Context, obj *productdto.ProductVariant) ([]*productdto.ProductVariantPrice, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.VariantPrice.Load(ctx, obj.ID)
 	dtos, err := thunk()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,128 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	graphqlgen "gobase/graphql/generated"
+	productdto "gobase/internal/domain/product/dto"
+	productmapper "gobase/internal/domain/product/mapper"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+	"gobase/internal/pkg/service/crud"
+
+	"github.com/google/uuid"
+)
+
+// CreateProductBundle is the resolver for the createProductBundle field.
+func (r *mutationResolver) CreateProductBundle(ctx context.Context, input productdto.CreateProductBundleInput) (*productdto.ProductBundle, error) {
+	return r.GraphQLResolver.Product.CreateBundle(ctx, input)
+}
+
+// UpdateProductBundle is the resolver for the updateProductBundle field.
+func (r *mutationResolver) UpdateProductBundle(ctx context.Context, input productdto.UpdateProductBundleInput) (*productdto.ProductBundle, error) {
+	return r.GraphQLResolver.Product.UpdateBundle(ctx, input)
+}
+
+// DeleteProductBundle is the resolver for the deleteProductBundle field.
+func (r *mutationResolver) DeleteProductBundle(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error) {
+	return r.GraphQLResolver.Product.DeleteBundle(ctx, id)
+}
+
+// Variant is the resolver for the variant field.
+func (r *productBundleResolver) Variant(ctx context.Context, obj *productdto.ProductBundle) (*productdto.ProductVariant, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.VariantByID.Load(ctx, obj.VariantID)
+	return thunk()
+}
+
+// Components is the resolver for the components field.
+func (r *productBundleResolver) Components(ctx context.Context, obj *productdto.ProductBundle) ([]*productdto.ProductBundleComponent, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.BundleComponent.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// AvailableStock is the resolver for the availableStock field.
+func (r *productBundleResolver) AvailableStock(ctx context.Context, obj *productdto.ProductBundle) (*int, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.BundleComponent.Load(ctx, obj.ID)
+	components, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+
+	variantIds := make([]uuid.UUID, len(components))
+	for i, component := range components {
+		variantIds[i] = component.VariantID
+	}
+
+	stocksThunk := loaders.Inventory.AvailableStock.LoadMany(ctx, variantIds)
+	stocks, errs := stocksThunk()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	availableStocks := make(map[uuid.UUID]*int, len(variantIds))
+	for i, variantId := range variantIds {
+		availableStocks[variantId] = stocks[i]
+	}
+	return productmapper.ProductBundleAvailableStock(components, availableStocks), nil
+}
+
+// Variant is the resolver for the variant field.
+func (r *productBundleComponentResolver) Variant(ctx context.Context, obj *productdto.ProductBundleComponent) (*productdto.ProductVariant, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.VariantByID.Load(ctx, obj.VariantID)
+	return thunk()
+}
+
+// Bundle is the resolver for the bundle field.
+func (r *productVariantResolver) Bundle(ctx context.Context, obj *productdto.ProductVariant) (*productdto.ProductBundle, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.Bundle.Load(ctx, obj.ID)
+	return thunk()
+}
+
+// ProductBundle is the resolver for the productBundle field.
+func (r *queryResolver) ProductBundle(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error) {
+	return r.GraphQLResolver.Product.FindBundleById(ctx, id)
+}
+
+// ProductBundles is the resolver for the productBundles field.
+func (r *queryResolver) ProductBundles(ctx context.Context, qop *productdto.ProductBundleQop) (*crud.PageResult[*productdto.ProductBundle], error) {
+	return r.GraphQLResolver.Product.FindAllBundles(ctx, qop)
+}
+
+// ProductBundle returns graphqlgen.ProductBundleResolver implementation.
+func (r *Resolver) ProductBundle() graphqlgen.ProductBundleResolver { return &productBundleResolver{r} }
+
+// ProductBundleComponent returns graphqlgen.ProductBundleComponentResolver implementation.
+func (r *Resolver) ProductBundleComponent() graphqlgen.ProductBundleComponentResolver {
+	return &productBundleComponentResolver{r}
+}
+
+type productBundleResolver struct{ *Resolver }
+type productBundleComponentResolver struct{ *Resolver }
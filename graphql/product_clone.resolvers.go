@@ -0,0 +1,17 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productdto "gobase/internal/domain/product/dto"
+
+	"github.com/google/uuid"
+)
+
+// CloneProduct is the resolver for the cloneProduct field.
+func (r *mutationResolver) CloneProduct(ctx context.Context, id uuid.UUID, overrides *productdto.CloneProductOverrides) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.Clone(ctx, id, overrides)
+}
@@ -0,0 +1,21 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/service/crud"
+)
+
+// ProductsConnection is the resolver for the productsConnection field.
+func (r *queryResolver) ProductsConnection(ctx context.Context, first *int, after *string, last *int, before *string, filters *productdto.ProductQopFilter, sorts []*crud.Sort, lang *string, search *string) (*crud.Connection[*productdto.Product], error) {
+	return r.GraphQLResolver.Product.FindConnection(ctx, crud.ConnectionArgs{
+		First:  first,
+		After:  after,
+		Last:   last,
+		Before: before,
+	}, filters, sorts, lang, search)
+}
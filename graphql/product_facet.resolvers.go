@@ -0,0 +1,32 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	graphqlgen "gobase/graphql/generated"
+	productdto "gobase/internal/domain/product/dto"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+)
+
+// Attribute is the resolver for the attribute field.
+func (r *productFacetResolver) Attribute(ctx context.Context, obj *productdto.ProductFacet) (*productdto.ProductAttribute, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.Attribute.Load(ctx, obj.AttributeID)
+	return thunk()
+}
+
+// ProductFacets is the resolver for the productFacets field.
+func (r *queryResolver) ProductFacets(ctx context.Context, qop *productdto.ProductQop, lang *string) ([]*productdto.ProductFacet, error) {
+	return r.GraphQLResolver.Product.FindFacets(ctx, qop, lang)
+}
+
+// ProductFacet returns graphqlgen.ProductFacetResolver implementation.
+func (r *Resolver) ProductFacet() graphqlgen.ProductFacetResolver { return &productFacetResolver{r} }
+
+type productFacetResolver struct{ *Resolver }
@@ -0,0 +1,15 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productdto "gobase/internal/domain/product/dto"
+)
+
+// ImportProducts is the resolver for the importProducts field.
+func (r *mutationResolver) ImportProducts(ctx context.Context, input productdto.ImportProductsInput) (*productdto.ImportProductsResult, error) {
+	return r.GraphQLResolver.Product.ImportProducts(ctx, input)
+}
@@ -0,0 +1,53 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	graphqlgen "gobase/graphql/generated"
+	productdto "gobase/internal/domain/product/dto"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+
+	"github.com/google/uuid"
+)
+
+// UploadProductMedia is the resolver for the uploadProductMedia field.
+func (r *mutationResolver) UploadProductMedia(ctx context.Context, input productdto.UploadProductMediaInput) (*productdto.ProductMedia, error) {
+	return r.GraphQLResolver.Product.UploadMedia(ctx, input)
+}
+
+// UpdateProductMedia is the resolver for the updateProductMedia field.
+func (r *mutationResolver) UpdateProductMedia(ctx context.Context, input productdto.UpdateProductMediaInput) (*productdto.ProductMedia, error) {
+	return r.GraphQLResolver.Product.UpdateMedia(ctx, input)
+}
+
+// DeleteProductMedia is the resolver for the deleteProductMedia field.
+func (r *mutationResolver) DeleteProductMedia(ctx context.Context, id uuid.UUID) (*productdto.ProductMedia, error) {
+	return r.GraphQLResolver.Product.DeleteMedia(ctx, id)
+}
+
+// Media is the resolver for the media field.
+func (r *productResolver) Media(ctx context.Context, obj *productdto.Product) ([]*productdto.ProductMedia, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.Media.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// URL is the resolver for the url field.
+func (r *productMediaResolver) URL(ctx context.Context, obj *productdto.ProductMedia) (string, error) {
+	return r.GraphQLResolver.Product.MediaURL(ctx, obj)
+}
+
+// ProductMedia returns graphqlgen.ProductMediaResolver implementation.
+func (r *Resolver) ProductMedia() graphqlgen.ProductMediaResolver { return &productMediaResolver{r} }
+
+type productMediaResolver struct{ *Resolver }
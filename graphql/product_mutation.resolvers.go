@@ -20,6 +20,11 @@ func (r *mutationResolver) CreateProductAttribute(ctx context.Context, input pro
 	return r.GraphQLResolver.Product.CreateAttribute(ctx, input)
 }
 
+// SetProductVariantPrices is the resolver for the setProductVariantPrices field.
+func (r *mutationResolver) SetProductVariantPrices(ctx context.Context, input productdto.SetProductVariantPricesInput) ([]*productdto.ProductVariantPrice, error) {
+	return r.GraphQLResolver.Product.SetVariantPrices(ctx, input)
+}
+
 // Mutation returns graphqlgen.MutationResolver implementation.
 func (r *Resolver) Mutation() graphqlgen.MutationResolver { return &mutationResolver{r} }
 
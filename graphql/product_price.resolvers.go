@@ -0,0 +1,51 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productloader "gobase/internal/domain/product/dataloader"
+	productdto "gobase/internal/domain/product/dto"
+	productmapper "gobase/internal/domain/product/mapper"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+	"time"
+)
+
+// ScheduleProductVariantPrice is the resolver for the scheduleProductVariantPrice field.
+func (r *mutationResolver) ScheduleProductVariantPrice(ctx context.Context, input productdto.ScheduleProductVariantPriceInput) (*productdto.ProductVariantPriceHistory, error) {
+	return r.GraphQLResolver.Product.SchedulePrice(ctx, input)
+}
+
+// EffectivePrice is the resolver for the effectivePrice field.
+func (r *productVariantResolver) EffectivePrice(ctx context.Context, obj *productdto.ProductVariant, at *time.Time) (*productdto.ProductVariantEffectivePrice, error) {
+	effectiveAt := time.Now()
+	if at != nil {
+		effectiveAt = *at
+	}
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.EffectivePrice.Load(ctx, productloader.NewEffectivePriceKey(obj.ID, effectiveAt))
+	dto, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return productmapper.ProductVariantEffectivePrice(obj, dto), nil
+}
+
+// PriceHistory is the resolver for the priceHistory field.
+func (r *productVariantResolver) PriceHistory(ctx context.Context, obj *productdto.ProductVariant) ([]*productdto.ProductVariantPriceHistory, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.PriceHistory.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
@@ -14,13 +14,13 @@ import (
 )
 
 // Product is the resolver for the product field.
-func (r *queryResolver) Product(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
-	return r.GraphQLResolver.Product.FindById(ctx, id)
+func (r *queryResolver) Product(ctx context.Context, id uuid.UUID, lang *string) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.FindById(ctx, id, lang)
 }
 
 // Products is the resolver for the products field.
-func (r *queryResolver) Products(ctx context.Context, qop *productdto.ProductQop) (*crud.PageResult[*productdto.Product], error) {
-	return r.GraphQLResolver.Product.FindAll(ctx, qop)
+func (r *queryResolver) Products(ctx context.Context, qop *productdto.ProductQop, lang *string, search *string) (*crud.PageResult[*productdto.Product], error) {
+	return r.GraphQLResolver.Product.FindAll(ctx, qop, lang, search)
 }
 
 // Query returns graphqlgen.QueryResolver implementation.
@@ -0,0 +1,28 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productloader "gobase/internal/domain/product/dataloader"
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper/language"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+)
+
+// RelatedProducts is the resolver for the relatedProducts field.
+func (r *productResolver) RelatedProducts(ctx context.Context, obj *productdto.Product, limit *int, categoryWeight *float64, tagWeight *float64) ([]*productdto.Product, error) {
+	key := productloader.NewRelatedProductsKey(obj.ID, limit, categoryWeight, tagWeight, language.FromContext(ctx))
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.RelatedProducts.Load(ctx, key)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
@@ -0,0 +1,29 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productloader "gobase/internal/domain/product/dataloader"
+	productdto "gobase/internal/domain/product/dto"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+)
+
+// Highlight is the resolver for the highlight field.
+func (r *productResolver) Highlight(ctx context.Context, obj *productdto.Product) (*productdto.ProductSearchHighlight, error) {
+	if obj.Search == "" {
+		return nil, nil
+	}
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.Highlight.Load(ctx, productloader.HighlightKey{
+		ProductID: obj.ID,
+		Search:    obj.Search,
+		Language:  obj.SearchLanguage,
+	})
+	return thunk()
+}
@@ -0,0 +1,42 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productdto "gobase/internal/domain/product/dto"
+
+	"github.com/google/uuid"
+)
+
+// SubmitProductForReview is the resolver for the submitProductForReview field.
+func (r *mutationResolver) SubmitProductForReview(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.TransitionStatus(ctx, id, productdto.ProductStatusTransitionSubmitForReview)
+}
+
+// RejectProduct is the resolver for the rejectProduct field.
+func (r *mutationResolver) RejectProduct(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.TransitionStatus(ctx, id, productdto.ProductStatusTransitionReject)
+}
+
+// PublishProduct is the resolver for the publishProduct field.
+func (r *mutationResolver) PublishProduct(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.TransitionStatus(ctx, id, productdto.ProductStatusTransitionPublish)
+}
+
+// UnpublishProduct is the resolver for the unpublishProduct field.
+func (r *mutationResolver) UnpublishProduct(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.TransitionStatus(ctx, id, productdto.ProductStatusTransitionUnpublish)
+}
+
+// ArchiveProduct is the resolver for the archiveProduct field.
+func (r *mutationResolver) ArchiveProduct(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.TransitionStatus(ctx, id, productdto.ProductStatusTransitionArchive)
+}
+
+// RestoreProduct is the resolver for the restoreProduct field.
+func (r *mutationResolver) RestoreProduct(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
+	return r.GraphQLResolver.Product.TransitionStatus(ctx, id, productdto.ProductStatusTransitionRestore)
+}
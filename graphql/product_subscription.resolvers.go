@@ -0,0 +1,28 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	graphqlgen "gobase/graphql/generated"
+	productdto "gobase/internal/domain/product/dto"
+
+	"github.com/google/uuid"
+)
+
+// ProductCreated is the resolver for the productCreated field.
+func (r *subscriptionResolver) ProductCreated(ctx context.Context) (<-chan *productdto.Product, error) {
+	return r.GraphQLResolver.Product.SubscribeCreated(ctx)
+}
+
+// ProductUpdated is the resolver for the productUpdated field.
+func (r *subscriptionResolver) ProductUpdated(ctx context.Context, id uuid.UUID) (<-chan *productdto.Product, error) {
+	return r.GraphQLResolver.Product.SubscribeUpdated(ctx, id)
+}
+
+// Subscription returns graphqlgen.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() graphqlgen.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
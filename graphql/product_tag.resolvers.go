@@ -0,0 +1,40 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productdto "gobase/internal/domain/product/dto"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+)
+
+// AddProductTags is the resolver for the addProductTags field.
+func (r *mutationResolver) AddProductTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error) {
+	return r.GraphQLResolver.Product.AddTags(ctx, input)
+}
+
+// RemoveProductTags is the resolver for the removeProductTags field.
+func (r *mutationResolver) RemoveProductTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error) {
+	return r.GraphQLResolver.Product.RemoveTags(ctx, input)
+}
+
+// Tags is the resolver for the tags field.
+func (r *productResolver) Tags(ctx context.Context, obj *productdto.Product) ([]*productdto.ProductTag, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.Tag.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+// ProductTags is the resolver for the productTags field.
+func (r *queryResolver) ProductTags(ctx context.Context, prefix *string, limit *int) ([]*productdto.ProductTag, error) {
+	return r.GraphQLResolver.Product.SuggestTags(ctx, prefix, limit)
+}
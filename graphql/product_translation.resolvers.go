@@ -0,0 +1,30 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.75
+
+import (
+	"context"
+	productdto "gobase/internal/domain/product/dto"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+)
+
+// SetProductTranslations is the resolver for the setProductTranslations field.
+func (r *mutationResolver) SetProductTranslations(ctx context.Context, input productdto.SetProductTranslationsInput) ([]*productdto.ProductTranslation, error) {
+	return r.GraphQLResolver.Product.SetTranslations(ctx, input)
+}
+
+// Translations is the resolver for the translations field.
+func (r *productResolver) Translations(ctx context.Context, obj *productdto.Product) ([]*productdto.ProductTranslation, error) {
+	loaders, err := middlewaregraphql.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loaders.Product.Translation.Load(ctx, obj.ID)
+	dtos, err := thunk()
+	if err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
@@ -0,0 +1,58 @@
+package masterdataentity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var _ bun.BeforeAppendModelHook = (*Category)(nil)
+var _ bun.BeforeUpdateHook = (*Category)(nil)
+var _ bun.BeforeDeleteHook = (*Category)(nil)
+
+type Category struct {
+	bun.BaseModel `bun:"table:category"`
+
+	Id       uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	ParentId uuid.UUID `bun:"parent_id,type:uuid,nullzero"`
+	Name     string    `validate:"required"`
+	Slug     string    `bun:"slug,unique" validate:"required"`
+	Position int
+
+	Parent *Category `bun:"rel:belongs-to,join:parent_id=id"`
+
+	Version   int
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	DeletedAt time.Time `bun:",soft_delete"`
+
+	IgnoreVersionOnUpdate bool `bun:"-" json:"-"`
+}
+
+func (u *Category) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		u.Version = 0
+	case *bun.UpdateQuery:
+		u.Version++
+	}
+	return nil
+}
+
+func (u *Category) BeforeUpdate(ctx context.Context, query *bun.UpdateQuery) error {
+	if !u.IgnoreVersionOnUpdate {
+		query.Where("version = ?", u.Version)
+	}
+	u.Version++
+	return nil
+}
+
+func (u *Category) BeforeDelete(ctx context.Context, query *bun.DeleteQuery) error {
+	if !u.IgnoreVersionOnUpdate {
+		query.Where("version = ?", u.Version)
+	}
+	u.Version++
+	return nil
+}
@@ -0,0 +1,19 @@
+package masterdataentity
+
+import (
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// CategoryClosure stores every ancestor-descendant pair of the category tree, including
+// the pair of each category with itself at depth 0.
+type CategoryClosure struct {
+	bun.BaseModel `bun:"table:category_closure"`
+
+	AncestorId   uuid.UUID `bun:"ancestor_id,pk,type:uuid" validate:"uuid,required"`
+	DescendantId uuid.UUID `bun:"descendant_id,pk,type:uuid" validate:"uuid,required"`
+	Depth        int       `bun:"depth,notnull"`
+
+	Ancestor   *Category `bun:"rel:belongs-to,join:ancestor_id=id"`
+	Descendant *Category `bun:"rel:belongs-to,join:descendant_id=id"`
+}
@@ -0,0 +1,54 @@
+package masterdataentity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var _ bun.BeforeAppendModelHook = (*InventoryLocation)(nil)
+var _ bun.BeforeUpdateHook = (*InventoryLocation)(nil)
+var _ bun.BeforeDeleteHook = (*InventoryLocation)(nil)
+
+type InventoryLocation struct {
+	bun.BaseModel `bun:"table:inventory_location"`
+
+	Id   uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	Code string    `bun:"code,unique" validate:"required"`
+	Name string    `validate:"required"`
+
+	Version   int
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	DeletedAt time.Time `bun:",soft_delete"`
+
+	IgnoreVersionOnUpdate bool `bun:"-" json:"-"`
+}
+
+func (u *InventoryLocation) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		u.Version = 0
+	case *bun.UpdateQuery:
+		u.Version++
+	}
+	return nil
+}
+
+func (u *InventoryLocation) BeforeUpdate(ctx context.Context, query *bun.UpdateQuery) error {
+	if !u.IgnoreVersionOnUpdate {
+		query.Where("version = ?", u.Version)
+	}
+	u.Version++
+	return nil
+}
+
+func (u *InventoryLocation) BeforeDelete(ctx context.Context, query *bun.DeleteQuery) error {
+	if !u.IgnoreVersionOnUpdate {
+		query.Where("version = ?", u.Version)
+	}
+	u.Version++
+	return nil
+}
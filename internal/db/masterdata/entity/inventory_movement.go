@@ -0,0 +1,25 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// InventoryMovement is an append-only ledger entry of a stock change. Rows are never updated or deleted.
+type InventoryMovement struct {
+	bun.BaseModel `bun:"table:inventory_movement"`
+
+	Id            uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	VariantId     uuid.UUID `bun:"product_variant_id,type:uuid" validate:"uuid,required"`
+	LocationId    uuid.UUID `bun:"location_id,type:uuid" validate:"uuid,required"`
+	ReservationId uuid.UUID `bun:"reservation_id,type:uuid,nullzero"`
+	Type          string    `bun:"type,notnull" validate:"required"`
+	OnHandDelta   int       `bun:"on_hand_delta,notnull"`
+	ReservedDelta int       `bun:"reserved_delta,notnull"`
+	OnHandAfter   int       `bun:"on_hand_after,notnull"`
+	ReservedAfter int       `bun:"reserved_after,notnull"`
+	Reason        string    `bun:"reason"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,24 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// InventoryReservation holds stock of a variant at a location until it is committed, released or expired.
+type InventoryReservation struct {
+	bun.BaseModel `bun:"table:inventory_reservation"`
+
+	Id         uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	VariantId  uuid.UUID `bun:"product_variant_id,type:uuid" validate:"uuid,required"`
+	LocationId uuid.UUID `bun:"location_id,type:uuid" validate:"uuid,required"`
+	Quantity   int       `bun:"quantity,notnull" validate:"gt=0"`
+	Status     string    `bun:"status,notnull" validate:"required"`
+	Reference  string    `bun:"reference"`
+	ExpiresAt  time.Time `bun:"expires_at,notnull"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,26 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// InventoryStock is the stock level of a variant at a location. It is only changed through
+// conditional updates, and every change is recorded in the InventoryMovement ledger.
+type InventoryStock struct {
+	bun.BaseModel `bun:"table:inventory_stock"`
+
+	Id         uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	VariantId  uuid.UUID `bun:"product_variant_id,type:uuid,unique:inventory_stock_variant_location" validate:"uuid,required"`
+	LocationId uuid.UUID `bun:"location_id,type:uuid,unique:inventory_stock_variant_location" validate:"uuid,required"`
+	OnHand     int       `bun:"on_hand,notnull"`
+	Reserved   int       `bun:"reserved,notnull"`
+
+	Variant  *ProductVariant    `bun:"rel:belongs-to,join:product_variant_id=id"`
+	Location *InventoryLocation `bun:"rel:belongs-to,join:location_id=id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
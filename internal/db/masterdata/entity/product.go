@@ -18,8 +18,10 @@ type Product struct {
 	Id          uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
 	Name        string    `validate:"required"`
 	Description string    `validate:"required"`
+	Status      string    `bun:"status,notnull" validate:"required"`
 
-	Variants []*ProductVariant `bun:"rel:has-many,join:id=product_id"`
+	Variants     []*ProductVariant     `bun:"rel:has-many,join:id=product_id"`
+	Translations []*ProductTranslation `bun:"rel:has-many,join:id=product_id"`
 
 	Version   int
 	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
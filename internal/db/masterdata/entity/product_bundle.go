@@ -0,0 +1,25 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// ProductBundle sells a set of variants together as the variant it is defined on, at its own price.
+type ProductBundle struct {
+	bun.BaseModel `bun:"table:product_bundle"`
+
+	Id        uuid.UUID       `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	VariantId uuid.UUID       `bun:"product_variant_id,type:uuid,notnull,unique" validate:"uuid,required"`
+	Price     decimal.Decimal `bun:"price,type:numeric(20,4),notnull"`
+	Currency  string          `bun:"currency,type:char(3),notnull" validate:"required"`
+
+	Variant    *ProductVariant           `bun:"rel:belongs-to,join:product_variant_id=id"`
+	Components []*ProductBundleComponent `bun:"rel:has-many,join:id=product_bundle_id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,23 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type ProductBundleComponent struct {
+	bun.BaseModel `bun:"table:product_bundle_component"`
+
+	Id        uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	BundleId  uuid.UUID `bun:"product_bundle_id,type:uuid,notnull,unique:product_bundle_component_bundle_variant" validate:"uuid,required"`
+	VariantId uuid.UUID `bun:"product_variant_id,type:uuid,notnull,unique:product_bundle_component_bundle_variant" validate:"uuid,required"`
+	Quantity  int       `bun:"quantity,notnull"`
+
+	Bundle  *ProductBundle  `bun:"rel:belongs-to,join:product_bundle_id=id"`
+	Variant *ProductVariant `bun:"rel:belongs-to,join:product_variant_id=id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
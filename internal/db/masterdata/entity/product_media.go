@@ -0,0 +1,29 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ProductMedia is an image or video of a product. The file itself is kept in the blob storage under StorageKey.
+type ProductMedia struct {
+	bun.BaseModel `bun:"table:product_media"`
+
+	Id          uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	ProductId   uuid.UUID `bun:"product_id,type:uuid,notnull" validate:"uuid,required"`
+	VariantId   uuid.UUID `bun:"product_variant_id,type:uuid,nullzero"`
+	StorageKey  string    `bun:"storage_key,notnull,unique" validate:"required"`
+	FileName    string    `bun:"file_name"`
+	ContentType string    `bun:"content_type,notnull" validate:"required"`
+	Size        int64     `bun:"size,notnull"`
+	AltText     string    `bun:"alt_text"`
+	Position    int       `bun:"position,notnull"`
+
+	Product *Product        `bun:"rel:belongs-to,join:product_id=id"`
+	Variant *ProductVariant `bun:"rel:belongs-to,join:product_variant_id=id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,16 @@
+package masterdataentity
+
+import (
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ProductSearch is the full-text search document of a product in a language. It is rebuilt from the
+// product, its translation and the SKUs of its variants whenever one of them changes.
+type ProductSearch struct {
+	bun.BaseModel `bun:"table:product_search"`
+
+	ProductId uuid.UUID `bun:"product_id,pk,type:uuid"`
+	Language  string    `bun:"language,pk"`
+	Document  string    `bun:"document,type:tsvector,notnull"`
+}
@@ -0,0 +1,22 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ProductTag is a free-form label of products. Tags are matched by their normalised Slug, Name keeps the text it was first added with.
+type ProductTag struct {
+	bun.BaseModel `bun:"table:product_tag"`
+
+	Id   uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	Slug string    `bun:"slug,notnull,unique" validate:"required"`
+	Name string    `bun:"name,notnull" validate:"required"`
+	// UsageCount is the number of products having the tag. It is refreshed whenever tags are added or removed.
+	UsageCount int `bun:"usage_count,notnull,default:0"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
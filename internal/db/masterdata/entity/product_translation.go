@@ -0,0 +1,22 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ProductTranslation is the name and description of a product in a language other than the default one.
+type ProductTranslation struct {
+	bun.BaseModel `bun:"table:product_translation"`
+
+	Id          uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	ProductId   uuid.UUID `bun:"product_id,type:uuid,notnull,unique:product_translation_product_language" validate:"uuid,required"`
+	Language    string    `bun:"language,notnull,unique:product_translation_product_language" validate:"required"`
+	Name        string    `bun:"name,notnull" validate:"required"`
+	Description string    `bun:"description"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/uptrace/bun"
 )
 
@@ -15,15 +16,17 @@ var _ bun.BeforeDeleteHook = (*ProductVariant)(nil)
 type ProductVariant struct {
 	bun.BaseModel `bun:"table:product_variant"`
 
-	Id              uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
-	ProductId       uuid.UUID `bun:"product_id,type:uuid" validate:"uuid,required"`
-	Name            string    `validate:"required"`
-	SKU             string    `validate:"required"`
-	Price           float64   `validate:"required"`
-	DiscountedPrice float64   `validate:"required"`
+	Id              uuid.UUID           `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	ProductId       uuid.UUID           `bun:"product_id,type:uuid" validate:"uuid,required"`
+	Name            string              `validate:"required"`
+	SKU             string              `validate:"required"`
+	Price           decimal.Decimal     `bun:"price,type:numeric(20,4),notnull"`
+	DiscountedPrice decimal.NullDecimal `bun:"discounted_price,type:numeric(20,4)"`
+	Currency        string              `bun:"currency,type:char(3),notnull,default:'IDR'" validate:"required"`
 
 	Product    *Product                             `bun:"rel:belongs-to,join:product_id=id"`
 	Attributes []*RelProductVariantProductAttribute `bun:"rel:has-many,join:id=product_variant_id"`
+	Prices     []*ProductVariantPrice               `bun:"rel:has-many,join:id=product_variant_id"`
 
 	Version   int
 	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
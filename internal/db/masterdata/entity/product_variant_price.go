@@ -0,0 +1,25 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// ProductVariantPrice is the price of a variant in a currency other than its base currency.
+type ProductVariantPrice struct {
+	bun.BaseModel `bun:"table:product_variant_price"`
+
+	Id              uuid.UUID           `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	VariantId       uuid.UUID           `bun:"product_variant_id,type:uuid,unique:product_variant_price_variant_currency" validate:"uuid,required"`
+	Currency        string              `bun:"currency,type:char(3),notnull,unique:product_variant_price_variant_currency" validate:"required"`
+	Price           decimal.Decimal     `bun:"price,type:numeric(20,4),notnull"`
+	DiscountedPrice decimal.NullDecimal `bun:"discounted_price,type:numeric(20,4)"`
+
+	Variant *ProductVariant `bun:"rel:belongs-to,join:product_variant_id=id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
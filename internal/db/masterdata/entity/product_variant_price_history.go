@@ -0,0 +1,30 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+)
+
+// ProductVariantPriceHistory is a price of a variant that is valid within a time window.
+// A zero ValidTo keeps the price valid until a later window replaces it.
+type ProductVariantPriceHistory struct {
+	bun.BaseModel `bun:"table:product_variant_price_history"`
+
+	Id              uuid.UUID           `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	VariantId       uuid.UUID           `bun:"product_variant_id,type:uuid,notnull" validate:"uuid,required"`
+	Currency        string              `bun:"currency,type:char(3),notnull" validate:"required"`
+	Price           decimal.Decimal     `bun:"price,type:numeric(20,4),notnull"`
+	DiscountedPrice decimal.NullDecimal `bun:"discounted_price,type:numeric(20,4)"`
+	ValidFrom       time.Time           `bun:"valid_from,notnull"`
+	ValidTo         time.Time           `bun:"valid_to,nullzero"`
+
+	// OpenedAt and ClosedAt are set once the opening and closing of the window have been published.
+	OpenedAt time.Time `bun:"opened_at,nullzero"`
+	ClosedAt time.Time `bun:"closed_at,nullzero"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,22 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RelProductCategory struct {
+	bun.BaseModel `bun:"table:rel_product_category"`
+
+	Id         uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	ProductId  uuid.UUID `bun:"product_id,type:uuid,unique:rel_product_category_product_category" validate:"uuid,required"`
+	CategoryId uuid.UUID `bun:"category_id,type:uuid,unique:rel_product_category_product_category" validate:"uuid,required"`
+
+	Product  *Product  `bun:"rel:belongs-to,join:product_id=id"`
+	Category *Category `bun:"rel:belongs-to,join:category_id=id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,22 @@
+package masterdataentity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RelProductTag struct {
+	bun.BaseModel `bun:"table:rel_product_tag"`
+
+	Id        uuid.UUID `bun:"id,pk,type:uuid" validate:"uuid,required"`
+	ProductId uuid.UUID `bun:"product_id,type:uuid,unique:rel_product_tag_product_tag" validate:"uuid,required"`
+	TagId     uuid.UUID `bun:"product_tag_id,type:uuid,unique:rel_product_tag_product_tag" validate:"uuid,required"`
+
+	Product *Product    `bun:"rel:belongs-to,join:product_id=id"`
+	Tag     *ProductTag `bun:"rel:belongs-to,join:product_tag_id=id"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
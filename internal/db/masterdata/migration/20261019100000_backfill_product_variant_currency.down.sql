@@ -0,0 +1,3 @@
+ALTER TABLE product_variant
+    ALTER COLUMN currency DROP NOT NULL,
+    ALTER COLUMN currency DROP DEFAULT;
@@ -0,0 +1,10 @@
+-- Variants created before prices carried a currency are priced in the default currency.
+ALTER TABLE product_variant ADD COLUMN IF NOT EXISTS currency char(3);
+
+UPDATE product_variant SET currency = 'IDR' WHERE currency IS NULL;
+UPDATE product_variant SET currency = upper(currency) WHERE currency <> upper(currency);
+UPDATE product_variant_price SET currency = upper(currency) WHERE currency <> upper(currency);
+
+ALTER TABLE product_variant
+    ALTER COLUMN currency SET DEFAULT 'IDR',
+    ALTER COLUMN currency SET NOT NULL;
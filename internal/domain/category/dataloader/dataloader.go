@@ -0,0 +1,123 @@
+package categoryloader
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	dataloader "github.com/graph-gophers/dataloader/v7"
+	"github.com/samber/lo"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	categorydto "gobase/internal/domain/category/dto"
+	categorymapper "gobase/internal/domain/category/mapper"
+	categoryrepo "gobase/internal/domain/category/repository"
+	"gobase/internal/pkg/service/gqldataloader"
+)
+
+// Dataloader holds all the dataloaders for the category domain.
+type Dataloader struct {
+	Category        *dataloader.Loader[uuid.UUID, []*categorydto.Category]
+	Children        *dataloader.Loader[uuid.UUID, []*categorydto.Category]
+	Ancestors       *dataloader.Loader[uuid.UUID, []*categorydto.Category]
+	ProductCategory *dataloader.Loader[uuid.UUID, []*categorydto.Category]
+}
+
+// NewDataloader creates a new set of dataloaders for the category domain. They are meant to be created per request.
+func NewDataloader(categoryRepo categoryrepo.Repository, opts gqldataloader.Options) *Dataloader {
+	return &Dataloader{
+		Category:        gqldataloader.NewLoader(newCategoryBatchFn(categoryRepo), opts),
+		Children:        gqldataloader.NewLoader(newChildrenBatchFn(categoryRepo), opts),
+		Ancestors:       gqldataloader.NewLoader(newAncestorsBatchFn(categoryRepo), opts),
+		ProductCategory: gqldataloader.NewLoader(newProductCategoryBatchFn(categoryRepo), opts),
+	}
+}
+
+// newCategoryBatchFn creates a batch function for loading categories by id using the generic batch function.
+func newCategoryBatchFn(repo categoryrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*categorydto.Category] {
+	return gqldataloader.NewGenericBatchFn(
+		repo.Category(),
+		[]string{"id"},
+		func(item *masterdataentity.Category) uuid.UUID {
+			return item.Id
+		},
+		nil,
+		categorymapper.CategoryEntityToDTO,
+	)
+}
+
+// newChildrenBatchFn creates a batch function for loading the direct children of categories using the generic batch function.
+func newChildrenBatchFn(repo categoryrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*categorydto.Category] {
+	return gqldataloader.NewGenericBatchFn(
+		repo.Category(),
+		[]string{"parent_id"},
+		func(item *masterdataentity.Category) uuid.UUID {
+			return item.ParentId
+		},
+		nil,
+		categorymapper.CategoryEntityToDTO,
+	)
+}
+
+// newAncestorsBatchFn creates a batch function for loading the ancestors of categories, ordered from the root.
+func newAncestorsBatchFn(repo categoryrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*categorydto.Category] {
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[[]*categorydto.Category] {
+		results := make([]*dataloader.Result[[]*categorydto.Category], len(keys))
+
+		closures, err := repo.FindAncestorsIn(ctx, keys)
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result[[]*categorydto.Category]{Error: err}
+			}
+			return results
+		}
+
+		grouped := lo.GroupBy(closures, func(item *masterdataentity.CategoryClosure) uuid.UUID {
+			return item.DescendantId
+		})
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result[[]*categorydto.Category]{
+				Data: lo.FilterMap(grouped[key], func(item *masterdataentity.CategoryClosure, _ int) (*categorydto.Category, bool) {
+					if item.Ancestor == nil {
+						return nil, false
+					}
+					return categorymapper.CategoryEntityToDTO(item.Ancestor), true
+				}),
+			}
+		}
+
+		return results
+	}
+}
+
+// newProductCategoryBatchFn creates a batch function for loading the categories assigned to products.
+func newProductCategoryBatchFn(repo categoryrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*categorydto.Category] {
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[[]*categorydto.Category] {
+		results := make([]*dataloader.Result[[]*categorydto.Category], len(keys))
+
+		rels, err := repo.FindProductCategoriesIn(ctx, keys)
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result[[]*categorydto.Category]{Error: err}
+			}
+			return results
+		}
+
+		grouped := lo.GroupBy(rels, func(item *masterdataentity.RelProductCategory) uuid.UUID {
+			return item.ProductId
+		})
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result[[]*categorydto.Category]{
+				Data: lo.FilterMap(grouped[key], func(item *masterdataentity.RelProductCategory, _ int) (*categorydto.Category, bool) {
+					if item.Category == nil {
+						return nil, false
+					}
+					return categorymapper.CategoryEntityToDTO(item.Category), true
+				}),
+			}
+		}
+
+		return results
+	}
+}
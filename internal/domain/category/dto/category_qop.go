@@ -0,0 +1,49 @@
+package categorydto
+
+import (
+	"github.com/google/uuid"
+	"k8s.io/utils/strings/slices"
+
+	"gobase/internal/pkg/service/crud"
+)
+
+// CategoryQopFilter defines the specific, allowed filters for categories.
+type CategoryQopFilter struct {
+	Name     *string    `filter:"field:name;operator:ilike"`
+	Slug     *string    `filter:"field:slug;operator:eq"`
+	ParentID *uuid.UUID `filter:"field:parent_id;operator:eq"`
+	// RootOnly limits the result to the top level categories.
+	RootOnly *bool
+}
+
+// CategoryQop (Query Options Provider) is an opinionated struct for category queries.
+type CategoryQop struct {
+	crud.QueryOptions
+	Filters CategoryQopFilter `json:"filters"`
+}
+
+// ToQueryOptions converts the opinionated CategoryQop to the generic crud.QueryOptions.
+func (q *CategoryQop) ToQueryOptions() *crud.QueryOptions {
+	qOpts := q.QueryOptions
+	qOpts.WithDefaultPagination()
+	qOpts.Filters = crud.BuildFilter(q.Filters)
+
+	if q.Filters.RootOnly != nil && *q.Filters.RootOnly {
+		qOpts.AndFilter(crud.Filter{Field: "parent_id", Operator: crud.OperatorIsNull})
+	}
+
+	return &qOpts
+}
+
+// WithAllowedSorts validates and sets the sorting options, ensuring only
+// whitelisted fields can be used for sorting.
+func (q *CategoryQop) WithAllowedSorts(allowedSorts []string) *CategoryQop {
+	var validatedSorts []crud.Sort
+	for _, s := range q.QueryOptions.Sorts {
+		if slices.Contains(allowedSorts, s.Field) {
+			validatedSorts = append(validatedSorts, s)
+		}
+	}
+	q.QueryOptions.Sorts = validatedSorts
+	return q
+}
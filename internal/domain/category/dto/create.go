@@ -0,0 +1,62 @@
+package categorydto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/helper"
+)
+
+type CreateCategoryInput struct {
+	Name     string     `json:"name" validate:"min=2"`
+	Slug     string     `json:"slug"`
+	ParentID *uuid.UUID `json:"parentId"`
+	Position int        `json:"position"`
+}
+
+func (c *CreateCategoryInput) ToEntity(isNew bool) *masterdataentity.Category {
+	category := &masterdataentity.Category{
+		Name:     c.Name,
+		Slug:     c.Slug,
+		Position: c.Position,
+	}
+
+	if category.Slug == "" {
+		category.Slug = helper.Slugify(c.Name)
+	}
+
+	if c.ParentID != nil {
+		category.ParentId = *c.ParentID
+	}
+
+	if isNew {
+		category.Id = uuid.New()
+		category.CreatedAt = time.Now()
+		category.Version = 1
+	} else {
+		category.UpdatedAt = time.Now()
+	}
+
+	return category
+}
+
+type UpdateCategoryInput struct {
+	ID       uuid.UUID `json:"id" validate:"required"`
+	Name     *string   `json:"name" validate:"omitempty,min=2"`
+	Slug     *string   `json:"slug" validate:"omitempty,min=1"`
+	Position *int      `json:"position"`
+}
+
+type MoveCategoryInput struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+	// ParentID is the new parent of the category. A nil ParentID moves the category to the root.
+	ParentID *uuid.UUID `json:"parentId"`
+	Position *int       `json:"position"`
+}
+
+type AssignProductsToCategoryInput struct {
+	CategoryID uuid.UUID   `json:"categoryId" validate:"required"`
+	ProductIDs []uuid.UUID `json:"productIds" validate:"min=1"`
+}
@@ -0,0 +1,7 @@
+package categorydto
+
+import (
+	"gobase/internal/pkg/service/crud"
+)
+
+type CategoryList = crud.PageResult[*Category]
@@ -0,0 +1,26 @@
+package categorymapper
+
+import (
+	"github.com/google/uuid"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	categorydto "gobase/internal/domain/category/dto"
+)
+
+func CategoryEntityToDTO(categoryEntity *masterdataentity.Category) *categorydto.Category {
+	category := &categorydto.Category{
+		ID:        categoryEntity.Id,
+		Name:      categoryEntity.Name,
+		Slug:      categoryEntity.Slug,
+		Position:  categoryEntity.Position,
+		CreatedAt: categoryEntity.CreatedAt,
+		UpdatedAt: categoryEntity.UpdatedAt,
+	}
+
+	if categoryEntity.ParentId != uuid.Nil {
+		parentId := categoryEntity.ParentId
+		category.ParentID = &parentId
+	}
+
+	return category
+}
@@ -0,0 +1,255 @@
+package categoryrepository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/service/buncrud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// Repository defines the data access layer for the Category aggregate.
+type Repository interface {
+	// WithTx returns a new repository instance that uses the provided transaction.
+	WithTx(ctx context.Context, tx bun.Tx) Repository
+
+	// Category returns a repository scoped to the Category entity.
+	Category() buncrud.BaseRepository[masterdataentity.Category]
+
+	// Closure returns a repository scoped to the ancestor-descendant pairs of the category tree.
+	Closure() buncrud.BaseRepository[masterdataentity.CategoryClosure]
+
+	// ProductCategory returns a repository for the product-category relationship.
+	ProductCategory() buncrud.BaseRepository[masterdataentity.RelProductCategory]
+
+	// InsertClosure links a new category to the tree under the given parent. A uuid.Nil parent makes it a root.
+	InsertClosure(ctx context.Context, categoryId uuid.UUID, parentId uuid.UUID) error
+
+	// MoveSubtree re-links the subtree of a category under a new parent. A uuid.Nil parent makes it a root.
+	MoveSubtree(ctx context.Context, categoryId uuid.UUID, parentId uuid.UUID) error
+
+	// DeleteClosure removes a leaf category from the tree.
+	DeleteClosure(ctx context.Context, categoryId uuid.UUID) error
+
+	// IsDescendant reports whether descendantId is categoryId itself or one of its descendants.
+	IsDescendant(ctx context.Context, categoryId uuid.UUID, descendantId uuid.UUID) (bool, error)
+
+	// FindAncestorsIn returns the ancestors of the given categories, ordered from the root.
+	// The pair of each category with itself is not included.
+	FindAncestorsIn(ctx context.Context, categoryIds []uuid.UUID) ([]*masterdataentity.CategoryClosure, error)
+
+	// FindDescendants returns the descendants of a category up to maxDepth levels deep. A maxDepth of 0 means unlimited.
+	FindDescendants(ctx context.Context, categoryId uuid.UUID, maxDepth int) ([]*masterdataentity.CategoryClosure, error)
+
+	// FindProductCategoriesIn returns the category assignments of the given products with their categories.
+	FindProductCategoriesIn(ctx context.Context, productIds []uuid.UUID) ([]*masterdataentity.RelProductCategory, error)
+
+	// ProductIdsInTreeQuery returns a subquery selecting the ids of the products assigned to
+	// a category or to any of its descendants.
+	ProductIdsInTreeQuery(categoryId uuid.UUID) *bun.SelectQuery
+}
+
+// RepositoryModule is the implementation of the Repository interface.
+type RepositoryModule struct {
+	categoriesRepo        buncrud.BaseRepository[masterdataentity.Category]
+	closuresRepo          buncrud.BaseRepository[masterdataentity.CategoryClosure]
+	productCategoriesRepo buncrud.BaseRepository[masterdataentity.RelProductCategory]
+	db                    bun.IDB // Can be *bun.DB or *bun.Tx
+}
+
+type RepositoryOpts struct {
+	Bun *bun.DB
+}
+
+// NewRepository creates a new repository for the Category aggregate.
+func NewRepository(opts RepositoryOpts) Repository {
+	return &RepositoryModule{
+		categoriesRepo:        buncrud.NewBaseRepository[masterdataentity.Category](opts.Bun),
+		closuresRepo:          buncrud.NewBaseRepository[masterdataentity.CategoryClosure](opts.Bun),
+		productCategoriesRepo: buncrud.NewBaseRepository[masterdataentity.RelProductCategory](opts.Bun),
+		db:                    opts.Bun,
+	}
+}
+
+// WithTx returns a new repository instance that uses the provided transaction.
+func (r *RepositoryModule) WithTx(ctx context.Context, tx bun.Tx) Repository {
+	return &RepositoryModule{
+		categoriesRepo:        r.categoriesRepo.WithTx(ctx, tx),
+		closuresRepo:          r.closuresRepo.WithTx(ctx, tx),
+		productCategoriesRepo: r.productCategoriesRepo.WithTx(ctx, tx),
+		db:                    tx,
+	}
+}
+
+func (r *RepositoryModule) Category() buncrud.BaseRepository[masterdataentity.Category] {
+	return r.categoriesRepo
+}
+
+func (r *RepositoryModule) Closure() buncrud.BaseRepository[masterdataentity.CategoryClosure] {
+	return r.closuresRepo
+}
+
+func (r *RepositoryModule) ProductCategory() buncrud.BaseRepository[masterdataentity.RelProductCategory] {
+	return r.productCategoriesRepo
+}
+
+func (r *RepositoryModule) InsertClosure(ctx context.Context, categoryId uuid.UUID, parentId uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "CategoryRepository/InsertClosure", map[string]any{
+		"categoryId": categoryId,
+		"parentId":   parentId,
+	})
+	defer span.End()
+
+	_, err := r.db.NewRaw(
+		`INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+		SELECT ancestor_id, ?, depth + 1 FROM category_closure WHERE descendant_id = ?
+		UNION ALL
+		SELECT ?, ?, 0`,
+		categoryId, parentId, categoryId, categoryId,
+	).Exec(ctx)
+
+	return err
+}
+
+func (r *RepositoryModule) MoveSubtree(ctx context.Context, categoryId uuid.UUID, parentId uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "CategoryRepository/MoveSubtree", map[string]any{
+		"categoryId": categoryId,
+		"parentId":   parentId,
+	})
+	defer span.End()
+
+	// Detach the subtree from its current ancestors.
+	_, err := r.db.NewRaw(
+		`DELETE FROM category_closure
+		WHERE descendant_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = ?)
+		AND ancestor_id NOT IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = ?)`,
+		categoryId, categoryId,
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if parentId == uuid.Nil {
+		return nil
+	}
+
+	// Attach the subtree to every ancestor of the new parent, including the parent itself.
+	_, err = r.db.NewRaw(
+		`INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+		SELECT super.ancestor_id, sub.descendant_id, super.depth + sub.depth + 1
+		FROM category_closure AS super
+		CROSS JOIN category_closure AS sub
+		WHERE super.descendant_id = ? AND sub.ancestor_id = ?`,
+		parentId, categoryId,
+	).Exec(ctx)
+
+	return err
+}
+
+func (r *RepositoryModule) DeleteClosure(ctx context.Context, categoryId uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "CategoryRepository/DeleteClosure", map[string]any{
+		"categoryId": categoryId,
+	})
+	defer span.End()
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.CategoryClosure)(nil)).
+		Where("descendant_id = ?", categoryId).
+		Exec(ctx)
+
+	return err
+}
+
+func (r *RepositoryModule) IsDescendant(ctx context.Context, categoryId uuid.UUID, descendantId uuid.UUID) (bool, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "CategoryRepository/IsDescendant", map[string]any{
+		"categoryId":   categoryId,
+		"descendantId": descendantId,
+	})
+	defer span.End()
+
+	return r.db.NewSelect().
+		Model((*masterdataentity.CategoryClosure)(nil)).
+		Where("ancestor_id = ?", categoryId).
+		Where("descendant_id = ?", descendantId).
+		Exists(ctx)
+}
+
+func (r *RepositoryModule) FindAncestorsIn(ctx context.Context, categoryIds []uuid.UUID) ([]*masterdataentity.CategoryClosure, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "CategoryRepository/FindAncestorsIn", map[string]any{
+		"categoryIds": categoryIds,
+	})
+	defer span.End()
+
+	var closures []*masterdataentity.CategoryClosure
+	if len(categoryIds) == 0 {
+		return closures, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&closures).
+		Relation("Ancestor").
+		Where("category_closure.descendant_id IN (?)", bun.In(categoryIds)).
+		Where("category_closure.depth > 0").
+		OrderExpr("category_closure.depth DESC").
+		Scan(ctx)
+
+	return closures, err
+}
+
+func (r *RepositoryModule) FindDescendants(ctx context.Context, categoryId uuid.UUID, maxDepth int) ([]*masterdataentity.CategoryClosure, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "CategoryRepository/FindDescendants", map[string]any{
+		"categoryId": categoryId,
+		"maxDepth":   maxDepth,
+	})
+	defer span.End()
+
+	var closures []*masterdataentity.CategoryClosure
+
+	query := r.db.NewSelect().
+		Model(&closures).
+		Relation("Descendant").
+		Where("category_closure.ancestor_id = ?", categoryId).
+		Where("category_closure.depth > 0").
+		OrderExpr("category_closure.depth ASC").
+		OrderExpr("descendant.position ASC")
+
+	if maxDepth > 0 {
+		query.Where("category_closure.depth <= ?", maxDepth)
+	}
+
+	err := query.Scan(ctx)
+
+	return closures, err
+}
+
+func (r *RepositoryModule) FindProductCategoriesIn(ctx context.Context, productIds []uuid.UUID) ([]*masterdataentity.RelProductCategory, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "CategoryRepository/FindProductCategoriesIn", map[string]any{
+		"productIds": productIds,
+	})
+	defer span.End()
+
+	var rels []*masterdataentity.RelProductCategory
+	if len(productIds) == 0 {
+		return rels, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&rels).
+		Relation("Category").
+		Where("rel_product_category.product_id IN (?)", bun.In(productIds)).
+		OrderExpr("category.position ASC").
+		Scan(ctx)
+
+	return rels, err
+}
+
+func (r *RepositoryModule) ProductIdsInTreeQuery(categoryId uuid.UUID) *bun.SelectQuery {
+	return r.db.NewSelect().
+		TableExpr("rel_product_category AS rpc").
+		Column("rpc.product_id").
+		Join("JOIN category_closure AS cc ON cc.descendant_id = rpc.category_id").
+		Where("cc.ancestor_id = ?", categoryId)
+}
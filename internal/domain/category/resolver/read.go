@@ -0,0 +1,29 @@
+package categoryresolver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	categorydto "gobase/internal/domain/category/dto"
+)
+
+func (r *ResolverModule) FindById(ctx context.Context, id uuid.UUID) (*categorydto.Category, error) {
+	return r.categoryUseCase.FindById(ctx, id)
+}
+
+func (r *ResolverModule) FindAll(ctx context.Context, qop *categorydto.CategoryQop) (*categorydto.CategoryList, error) {
+	return r.categoryUseCase.FindAll(ctx, qop)
+}
+
+func (r *ResolverModule) FindDescendants(ctx context.Context, id uuid.UUID, maxDepth *int) ([]*categorydto.Category, error) {
+	depth := 0
+	if maxDepth != nil {
+		depth = *maxDepth
+	}
+	return r.categoryUseCase.FindDescendants(ctx, id, depth)
+}
+
+func (r *ResolverModule) FindBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*categorydto.Category, error) {
+	return r.categoryUseCase.FindBreadcrumbs(ctx, id)
+}
@@ -0,0 +1,41 @@
+package categoryresolver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	categorydto "gobase/internal/domain/category/dto"
+	categoryusecase "gobase/internal/domain/category/usecase"
+)
+
+// Resolver is the interface for the category domain's GraphQL queries and mutations.
+type Resolver interface {
+	Create(ctx context.Context, input categorydto.CreateCategoryInput) (*categorydto.Category, error)
+	Update(ctx context.Context, input categorydto.UpdateCategoryInput) (*categorydto.Category, error)
+	Move(ctx context.Context, input categorydto.MoveCategoryInput) (*categorydto.Category, error)
+	Delete(ctx context.Context, id uuid.UUID) (bool, error)
+	AssignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error)
+	UnassignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error)
+	FindById(ctx context.Context, id uuid.UUID) (*categorydto.Category, error)
+	FindAll(ctx context.Context, qop *categorydto.CategoryQop) (*categorydto.CategoryList, error)
+	FindDescendants(ctx context.Context, id uuid.UUID, maxDepth *int) ([]*categorydto.Category, error)
+	FindBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*categorydto.Category, error)
+}
+
+// ResolverModule is the implementation of the Resolver interface.
+type ResolverModule struct {
+	categoryUseCase categoryusecase.UseCase
+}
+
+// ResolverOptions holds the dependencies needed to create a new resolver.
+type ResolverOptions struct {
+	CategoryUseCase categoryusecase.UseCase
+}
+
+// NewResolver creates a new, configured category resolver.
+func NewResolver(opts ResolverOptions) Resolver {
+	return &ResolverModule{
+		categoryUseCase: opts.CategoryUseCase,
+	}
+}
@@ -0,0 +1,36 @@
+package categoryresolver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	categorydto "gobase/internal/domain/category/dto"
+)
+
+func (r *ResolverModule) Create(ctx context.Context, input categorydto.CreateCategoryInput) (*categorydto.Category, error) {
+	return r.categoryUseCase.Create(ctx, input)
+}
+
+func (r *ResolverModule) Update(ctx context.Context, input categorydto.UpdateCategoryInput) (*categorydto.Category, error) {
+	return r.categoryUseCase.Update(ctx, input)
+}
+
+func (r *ResolverModule) Move(ctx context.Context, input categorydto.MoveCategoryInput) (*categorydto.Category, error) {
+	return r.categoryUseCase.Move(ctx, input)
+}
+
+func (r *ResolverModule) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	if err := r.categoryUseCase.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ResolverModule) AssignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error) {
+	return r.categoryUseCase.AssignProducts(ctx, input)
+}
+
+func (r *ResolverModule) UnassignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error) {
+	return r.categoryUseCase.UnassignProducts(ctx, input)
+}
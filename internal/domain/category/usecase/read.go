@@ -0,0 +1,100 @@
+package categoryusecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	categorydto "gobase/internal/domain/category/dto"
+	categorymapper "gobase/internal/domain/category/mapper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+func (m *UseCaseModule) FindById(ctx context.Context, id uuid.UUID) (*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/FindById")
+	defer span.End()
+
+	categoryEntity, err := m.repository.Category().FindByID(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return categorymapper.CategoryEntityToDTO(categoryEntity), nil
+}
+
+func (m *UseCaseModule) FindAll(ctx context.Context, qop *categorydto.CategoryQop) (*crud.PageResult[*categorydto.Category], error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/FindAll")
+	defer span.End()
+
+	options := crud.NewQueryOptions()
+
+	if qop != nil {
+		qop.WithAllowedSorts([]string{"id", "name", "slug", "position", "created_at", "updated_at"})
+		options = qop.ToQueryOptions()
+	}
+
+	if len(options.Sorts) == 0 {
+		options.WithSort("position", "ASC")
+	}
+
+	entityResult, err := m.repository.Category().FindAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryDTOs := make([]*categorydto.Category, len(entityResult.Items))
+	for i, c := range entityResult.Items {
+		categoryDTOs[i] = categorymapper.CategoryEntityToDTO(&c)
+	}
+
+	return &crud.PageResult[*categorydto.Category]{
+		Items:      categoryDTOs,
+		Pagination: entityResult.Pagination,
+	}, nil
+}
+
+// FindDescendants returns the descendants of a category, level by level. A maxDepth of 0 returns the whole subtree.
+func (m *UseCaseModule) FindDescendants(ctx context.Context, id uuid.UUID, maxDepth int) ([]*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/FindDescendants")
+	defer span.End()
+
+	closures, err := m.repository.FindDescendants(ctx, id, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.FilterMap(closures, func(closure *masterdataentity.CategoryClosure, _ int) (*categorydto.Category, bool) {
+		if closure.Descendant == nil {
+			return nil, false
+		}
+		return categorymapper.CategoryEntityToDTO(closure.Descendant), true
+	}), nil
+}
+
+// FindBreadcrumbs returns the path from the root category down to the given category, inclusive.
+func (m *UseCaseModule) FindBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/FindBreadcrumbs")
+	defer span.End()
+
+	category, err := m.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	closures, err := m.repository.FindAncestorsIn(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	breadcrumbs := lo.FilterMap(closures, func(closure *masterdataentity.CategoryClosure, _ int) (*categorydto.Category, bool) {
+		if closure.Ancestor == nil {
+			return nil, false
+		}
+		return categorymapper.CategoryEntityToDTO(closure.Ancestor), true
+	})
+
+	return append(breadcrumbs, category), nil
+}
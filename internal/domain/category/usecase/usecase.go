@@ -0,0 +1,57 @@
+package categoryusecase
+
+import (
+	"context"
+
+	"clodeo.tech/public/go-universe/pkg/localization"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	categorydto "gobase/internal/domain/category/dto"
+	categoryrepository "gobase/internal/domain/category/repository"
+	productrepository "gobase/internal/domain/product/repository"
+	"gobase/internal/pkg/service/crud"
+	structprocessor "gobase/internal/pkg/service/structprocessor"
+)
+
+// languageId is the language of the error messages returned by the category use case.
+const languageId = "id"
+
+type UseCase interface {
+	Create(ctx context.Context, input categorydto.CreateCategoryInput) (*categorydto.Category, error)
+	Update(ctx context.Context, input categorydto.UpdateCategoryInput) (*categorydto.Category, error)
+	Move(ctx context.Context, input categorydto.MoveCategoryInput) (*categorydto.Category, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	AssignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error)
+	UnassignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error)
+	FindById(ctx context.Context, id uuid.UUID) (*categorydto.Category, error)
+	FindAll(ctx context.Context, qop *categorydto.CategoryQop) (*crud.PageResult[*categorydto.Category], error)
+	FindDescendants(ctx context.Context, id uuid.UUID, maxDepth int) ([]*categorydto.Category, error)
+	FindBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*categorydto.Category, error)
+}
+
+type UseCaseModule struct {
+	bun               *bun.DB
+	repository        categoryrepository.Repository
+	productRepository productrepository.Repository
+	sp                structprocessor.StructProcessorService
+	localizer         localization.Localizer
+}
+
+type UseCaseOpts struct {
+	Bun               *bun.DB
+	Repository        categoryrepository.Repository
+	ProductRepository productrepository.Repository
+	SP                structprocessor.StructProcessorService
+	Localizer         localization.Localizer
+}
+
+func NewUseCase(opts UseCaseOpts) UseCase {
+	return &UseCaseModule{
+		bun:               opts.Bun,
+		repository:        opts.Repository,
+		productRepository: opts.ProductRepository,
+		sp:                opts.SP,
+		localizer:         opts.Localizer,
+	}
+}
@@ -0,0 +1,285 @@
+package categoryusecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	categorydto "gobase/internal/domain/category/dto"
+	categorymapper "gobase/internal/domain/category/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+func (m *UseCaseModule) Create(ctx context.Context, input categorydto.CreateCategoryInput) (*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/Create")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryEntity := input.ToEntity(true)
+
+	if categoryEntity.ParentId != uuid.Nil {
+		if err = m.ensureCategoryExists(ctx, categoryEntity.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	// The transaction will handle the creation of the category and its place in the tree.
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		_, err := txRepo.Category().Create(ctx, categoryEntity)
+		if err != nil {
+			return err
+		}
+
+		return txRepo.InsertClosure(ctx, categoryEntity.Id, categoryEntity.ParentId)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return categorymapper.CategoryEntityToDTO(categoryEntity), nil
+}
+
+func (m *UseCaseModule) Update(ctx context.Context, input categorydto.UpdateCategoryInput) (*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/Update")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryEntity, err := m.findCategoryEntity(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		categoryEntity.Name = *input.Name
+	}
+	if input.Slug != nil {
+		categoryEntity.Slug = helper.Slugify(*input.Slug)
+	}
+	if input.Position != nil {
+		categoryEntity.Position = *input.Position
+	}
+
+	updatedCategory, err := m.repository.Category().Update(ctx, categoryEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return categorymapper.CategoryEntityToDTO(updatedCategory), nil
+}
+
+func (m *UseCaseModule) Move(ctx context.Context, input categorydto.MoveCategoryInput) (*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/Move")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryEntity, err := m.findCategoryEntity(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	parentId := uuid.Nil
+	if input.ParentID != nil {
+		parentId = *input.ParentID
+
+		if err = m.ensureCategoryExists(ctx, parentId); err != nil {
+			return nil, err
+		}
+
+		// A category can not be moved under itself or one of its descendants.
+		isDescendant, err := m.repository.IsDescendant(ctx, categoryEntity.Id, parentId)
+		if err != nil {
+			return nil, err
+		}
+		if isDescendant {
+			return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorCategoryMoveIntoOwnSubtree", nil)
+		}
+	}
+
+	categoryEntity.ParentId = parentId
+	if input.Position != nil {
+		categoryEntity.Position = *input.Position
+	}
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		_, err := txRepo.Category().Update(ctx, categoryEntity)
+		if err != nil {
+			return err
+		}
+
+		return txRepo.MoveSubtree(ctx, categoryEntity.Id, parentId)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return categorymapper.CategoryEntityToDTO(categoryEntity), nil
+}
+
+// Delete removes a category that has no children, together with its product assignments.
+func (m *UseCaseModule) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/Delete")
+	defer span.End()
+
+	categoryEntity, err := m.findCategoryEntity(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	hasChildren, err := m.repository.Category().QueryBuilder(ctx, nil).Where("parent_id = ?", id).Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorCategoryHasChildren", nil)
+	}
+
+	return m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := helper.UpdateDeleteWithRowsAffectedWrapper(m.localizer, languageId, func() (int64, error) {
+			res, err := tx.NewDelete().Model(categoryEntity).WherePK().Exec(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return res.RowsAffected()
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewDelete().
+			Model((*masterdataentity.RelProductCategory)(nil)).
+			Where("category_id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return m.repository.WithTx(ctx, tx).DeleteClosure(ctx, id)
+	})
+}
+
+// AssignProducts assigns the products to a category. Products that are already assigned are left untouched.
+func (m *UseCaseModule) AssignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/AssignProducts")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryEntity, err := m.findCategoryEntity(ctx, input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	productIds := lo.Uniq(input.ProductIDs)
+	products, err := m.productRepository.Product().FindIn(ctx, "id", lo.ToAnySlice(productIds), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) != len(productIds) {
+		return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "Product")
+	}
+
+	rels := lo.Map(productIds, func(productId uuid.UUID, _ int) *masterdataentity.RelProductCategory {
+		return &masterdataentity.RelProductCategory{
+			Id:         uuid.New(),
+			ProductId:  productId,
+			CategoryId: categoryEntity.Id,
+		}
+	})
+
+	_, err = m.bun.NewInsert().
+		Model(&rels).
+		On("CONFLICT (product_id, category_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return categorymapper.CategoryEntityToDTO(categoryEntity), nil
+}
+
+func (m *UseCaseModule) UnassignProducts(ctx context.Context, input categorydto.AssignProductsToCategoryInput) (*categorydto.Category, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "CategoryUseCase/UnassignProducts")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryEntity, err := m.findCategoryEntity(ctx, input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.bun.NewDelete().
+		Model((*masterdataentity.RelProductCategory)(nil)).
+		Where("category_id = ?", categoryEntity.Id).
+		Where("product_id IN (?)", bun.In(input.ProductIDs)).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return categorymapper.CategoryEntityToDTO(categoryEntity), nil
+}
+
+func (m *UseCaseModule) findCategoryEntity(ctx context.Context, id uuid.UUID) (*masterdataentity.Category, error) {
+	categoryEntity, err := m.repository.Category().FindByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "Category")
+		}
+		return nil, err
+	}
+
+	return categoryEntity, nil
+}
+
+func (m *UseCaseModule) ensureCategoryExists(ctx context.Context, id uuid.UUID) error {
+	exists, err := m.repository.Category().Exists(ctx, id.String())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ParentCategory")
+	}
+
+	return nil
+}
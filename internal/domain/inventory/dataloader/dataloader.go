@@ -0,0 +1,68 @@
+package inventoryloader
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	dataloader "github.com/graph-gophers/dataloader/v7"
+	"github.com/samber/lo"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	inventorydto "gobase/internal/domain/inventory/dto"
+	inventorymapper "gobase/internal/domain/inventory/mapper"
+	inventoryrepo "gobase/internal/domain/inventory/repository"
+	"gobase/internal/pkg/service/gqldataloader"
+)
+
+// Dataloader holds all the dataloaders for the inventory domain.
+type Dataloader struct {
+	Stock *dataloader.Loader[uuid.UUID, []*inventorydto.InventoryStock]
+	// AvailableStock loads nil for the variants whose stock is not tracked, i.e. that have no stock at any location.
+	AvailableStock *dataloader.Loader[uuid.UUID, *int]
+}
+
+// NewDataloader creates a new set of dataloaders for the inventory domain. They are meant to be created per request.
+func NewDataloader(inventoryRepo inventoryrepo.Repository, opts gqldataloader.Options) *Dataloader {
+	return &Dataloader{
+		Stock:          gqldataloader.NewLoader(newStockBatchFn(inventoryRepo), opts),
+		AvailableStock: gqldataloader.NewLoader(newAvailableStockBatchFn(inventoryRepo), opts),
+	}
+}
+
+// newStockBatchFn creates a batch function for loading the stock of variants per location using the generic batch function.
+func newStockBatchFn(repo inventoryrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*inventorydto.InventoryStock] {
+	return gqldataloader.NewGenericBatchFn(
+		repo.Stock(),
+		[]string{"product_variant_id"},
+		func(item *masterdataentity.InventoryStock) uuid.UUID {
+			return item.VariantId
+		},
+		nil,
+		inventorymapper.InventoryStockEntityToDTO,
+	)
+}
+
+// newAvailableStockBatchFn creates a batch function for loading the available stock of variants over every location.
+func newAvailableStockBatchFn(repo inventoryrepo.Repository) dataloader.BatchFunc[uuid.UUID, *int] {
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[*int] {
+		results := make([]*dataloader.Result[*int], len(keys))
+
+		availabilities, err := repo.FindAvailabilityIn(ctx, keys)
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result[*int]{Error: err}
+			}
+			return results
+		}
+
+		available := lo.SliceToMap(availabilities, func(item *inventoryrepo.VariantAvailability) (uuid.UUID, *int) {
+			return item.VariantId, &item.Available
+		})
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result[*int]{Data: available[key]}
+		}
+
+		return results
+	}
+}
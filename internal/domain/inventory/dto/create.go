@@ -0,0 +1,49 @@
+package inventorydto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+)
+
+type CreateInventoryLocationInput struct {
+	Code string `json:"code" validate:"min=1"`
+	Name string `json:"name" validate:"min=2"`
+}
+
+func (c *CreateInventoryLocationInput) ToEntity(isNew bool) *masterdataentity.InventoryLocation {
+	location := &masterdataentity.InventoryLocation{
+		Code: c.Code,
+		Name: c.Name,
+	}
+
+	if isNew {
+		location.Id = uuid.New()
+		location.CreatedAt = time.Now()
+		location.Version = 1
+	} else {
+		location.UpdatedAt = time.Now()
+	}
+
+	return location
+}
+
+type AdjustStockInput struct {
+	VariantID  uuid.UUID `json:"variantId" validate:"required"`
+	LocationID uuid.UUID `json:"locationId" validate:"required"`
+	// Quantity is added to the stock on hand. A negative quantity removes stock.
+	Quantity int    `json:"quantity" validate:"required"`
+	Reason   string `json:"reason"`
+}
+
+type ReserveStockInput struct {
+	VariantID  uuid.UUID `json:"variantId" validate:"required"`
+	LocationID uuid.UUID `json:"locationId" validate:"required"`
+	Quantity   int       `json:"quantity" validate:"gt=0"`
+	// Reference identifies the owner of the reservation, e.g. a cart id.
+	Reference string `json:"reference"`
+	// TTLSeconds overrides the default lifetime of the reservation.
+	TTLSeconds *int `json:"ttlSeconds" validate:"omitempty,gt=0"`
+}
@@ -0,0 +1,72 @@
+package inventorydto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InventoryMovementType is the kind of stock change recorded in the movement ledger.
+type InventoryMovementType string
+
+const (
+	InventoryMovementTypeAdjustment InventoryMovementType = "ADJUSTMENT"
+	InventoryMovementTypeReserve    InventoryMovementType = "RESERVE"
+	InventoryMovementTypeRelease    InventoryMovementType = "RELEASE"
+	InventoryMovementTypeCommit     InventoryMovementType = "COMMIT"
+	InventoryMovementTypeExpire     InventoryMovementType = "EXPIRE"
+)
+
+// InventoryReservationStatus is the state of a stock reservation. Only pending reservations hold stock.
+type InventoryReservationStatus string
+
+const (
+	InventoryReservationStatusPending   InventoryReservationStatus = "PENDING"
+	InventoryReservationStatusReleased  InventoryReservationStatus = "RELEASED"
+	InventoryReservationStatusCommitted InventoryReservationStatus = "COMMITTED"
+	InventoryReservationStatusExpired   InventoryReservationStatus = "EXPIRED"
+)
+
+type InventoryLocation struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type InventoryStock struct {
+	ID         uuid.UUID `json:"id"`
+	VariantID  uuid.UUID `json:"variant_id"`
+	LocationID uuid.UUID `json:"location_id"`
+	OnHand     int       `json:"on_hand"`
+	Reserved   int       `json:"reserved"`
+	Available  int       `json:"available"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type InventoryMovement struct {
+	ID            uuid.UUID             `json:"id"`
+	VariantID     uuid.UUID             `json:"variant_id"`
+	LocationID    uuid.UUID             `json:"location_id"`
+	ReservationID *uuid.UUID            `json:"reservation_id"`
+	Type          InventoryMovementType `json:"type"`
+	OnHandDelta   int                   `json:"on_hand_delta"`
+	ReservedDelta int                   `json:"reserved_delta"`
+	OnHandAfter   int                   `json:"on_hand_after"`
+	ReservedAfter int                   `json:"reserved_after"`
+	Reason        string                `json:"reason"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+type InventoryReservation struct {
+	ID         uuid.UUID                  `json:"id"`
+	VariantID  uuid.UUID                  `json:"variant_id"`
+	LocationID uuid.UUID                  `json:"location_id"`
+	Quantity   int                        `json:"quantity"`
+	Status     InventoryReservationStatus `json:"status"`
+	Reference  string                     `json:"reference"`
+	ExpiresAt  time.Time                  `json:"expires_at"`
+	CreatedAt  time.Time                  `json:"created_at"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+}
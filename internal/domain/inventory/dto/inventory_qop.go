@@ -0,0 +1,43 @@
+package inventorydto
+
+import (
+	"github.com/google/uuid"
+	"k8s.io/utils/strings/slices"
+
+	"gobase/internal/pkg/service/crud"
+)
+
+// InventoryMovementQopFilter defines the specific, allowed filters for the movement ledger.
+type InventoryMovementQopFilter struct {
+	VariantID     *uuid.UUID             `filter:"field:product_variant_id;operator:eq"`
+	LocationID    *uuid.UUID             `filter:"field:location_id;operator:eq"`
+	ReservationID *uuid.UUID             `filter:"field:reservation_id;operator:eq"`
+	Type          *InventoryMovementType `filter:"field:type;operator:eq"`
+}
+
+// InventoryMovementQop (Query Options Provider) is an opinionated struct for movement ledger queries.
+type InventoryMovementQop struct {
+	crud.QueryOptions
+	Filters InventoryMovementQopFilter `json:"filters"`
+}
+
+// ToQueryOptions converts the opinionated InventoryMovementQop to the generic crud.QueryOptions.
+func (q *InventoryMovementQop) ToQueryOptions() *crud.QueryOptions {
+	qOpts := q.QueryOptions
+	qOpts.WithDefaultPagination()
+	qOpts.Filters = crud.BuildFilter(q.Filters)
+	return &qOpts
+}
+
+// WithAllowedSorts validates and sets the sorting options, ensuring only
+// whitelisted fields can be used for sorting.
+func (q *InventoryMovementQop) WithAllowedSorts(allowedSorts []string) *InventoryMovementQop {
+	var validatedSorts []crud.Sort
+	for _, s := range q.QueryOptions.Sorts {
+		if slices.Contains(allowedSorts, s.Field) {
+			validatedSorts = append(validatedSorts, s)
+		}
+	}
+	q.QueryOptions.Sorts = validatedSorts
+	return q
+}
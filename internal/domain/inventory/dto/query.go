@@ -0,0 +1,9 @@
+package inventorydto
+
+import (
+	"gobase/internal/pkg/service/crud"
+)
+
+type InventoryLocationList = crud.PageResult[*InventoryLocation]
+
+type InventoryMovementList = crud.PageResult[*InventoryMovement]
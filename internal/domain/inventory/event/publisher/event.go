@@ -0,0 +1,66 @@
+package inventoryeventpublisher
+
+import (
+	"context"
+
+	wsql "github.com/ThreeDotsLabs/watermill-sql/v2/pkg/sql"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/service/watermillsvc"
+)
+
+type Event interface {
+	PublishStockAdjusted(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error
+	PublishStockReserved(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error
+	PublishReservationReleased(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error
+	PublishReservationCommitted(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error
+	PublishReservationExpired(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error
+}
+
+type EventModule struct {
+	watermillsvc watermillsvc.Service
+}
+
+type EventOpts struct {
+	Watermillsvc watermillsvc.Service
+}
+
+func NewEvent(opts EventOpts) Event {
+	event := &EventModule{
+		watermillsvc: opts.Watermillsvc,
+	}
+
+	return event
+}
+
+func (m *EventModule) PublishStockAdjusted(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error {
+	return m.publish(ctx, tx, "inventory.stock_adjusted", movement)
+}
+
+func (m *EventModule) PublishStockReserved(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error {
+	return m.publish(ctx, tx, "inventory.stock_reserved", movement)
+}
+
+func (m *EventModule) PublishReservationReleased(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error {
+	return m.publish(ctx, tx, "inventory.reservation_released", movement)
+}
+
+func (m *EventModule) PublishReservationCommitted(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error {
+	return m.publish(ctx, tx, "inventory.reservation_committed", movement)
+}
+
+func (m *EventModule) PublishReservationExpired(ctx context.Context, tx wsql.ContextExecutor, movement *masterdataentity.InventoryMovement) error {
+	return m.publish(ctx, tx, "inventory.reservation_expired", movement)
+}
+
+func (m *EventModule) publish(ctx context.Context, tx wsql.ContextExecutor, topic string, movement *masterdataentity.InventoryMovement) error {
+	msg, err := watermillsvc.BuildNewMessage(ctx, movement)
+	if err != nil {
+		return err
+	}
+	publisher, err := m.watermillsvc.WithTx(tx)
+	if err != nil {
+		return err
+	}
+	return publisher.Publish(topic, msg)
+}
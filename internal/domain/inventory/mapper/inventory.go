@@ -0,0 +1,66 @@
+package inventorymapper
+
+import (
+	"github.com/google/uuid"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	inventorydto "gobase/internal/domain/inventory/dto"
+)
+
+func InventoryLocationEntityToDTO(locationEntity *masterdataentity.InventoryLocation) *inventorydto.InventoryLocation {
+	return &inventorydto.InventoryLocation{
+		ID:        locationEntity.Id,
+		Code:      locationEntity.Code,
+		Name:      locationEntity.Name,
+		CreatedAt: locationEntity.CreatedAt,
+		UpdatedAt: locationEntity.UpdatedAt,
+	}
+}
+
+func InventoryStockEntityToDTO(stockEntity *masterdataentity.InventoryStock) *inventorydto.InventoryStock {
+	return &inventorydto.InventoryStock{
+		ID:         stockEntity.Id,
+		VariantID:  stockEntity.VariantId,
+		LocationID: stockEntity.LocationId,
+		OnHand:     stockEntity.OnHand,
+		Reserved:   stockEntity.Reserved,
+		Available:  stockEntity.OnHand - stockEntity.Reserved,
+		UpdatedAt:  stockEntity.UpdatedAt,
+	}
+}
+
+func InventoryMovementEntityToDTO(movementEntity *masterdataentity.InventoryMovement) *inventorydto.InventoryMovement {
+	movement := &inventorydto.InventoryMovement{
+		ID:            movementEntity.Id,
+		VariantID:     movementEntity.VariantId,
+		LocationID:    movementEntity.LocationId,
+		Type:          inventorydto.InventoryMovementType(movementEntity.Type),
+		OnHandDelta:   movementEntity.OnHandDelta,
+		ReservedDelta: movementEntity.ReservedDelta,
+		OnHandAfter:   movementEntity.OnHandAfter,
+		ReservedAfter: movementEntity.ReservedAfter,
+		Reason:        movementEntity.Reason,
+		CreatedAt:     movementEntity.CreatedAt,
+	}
+
+	if movementEntity.ReservationId != uuid.Nil {
+		reservationId := movementEntity.ReservationId
+		movement.ReservationID = &reservationId
+	}
+
+	return movement
+}
+
+func InventoryReservationEntityToDTO(reservationEntity *masterdataentity.InventoryReservation) *inventorydto.InventoryReservation {
+	return &inventorydto.InventoryReservation{
+		ID:         reservationEntity.Id,
+		VariantID:  reservationEntity.VariantId,
+		LocationID: reservationEntity.LocationId,
+		Quantity:   reservationEntity.Quantity,
+		Status:     inventorydto.InventoryReservationStatus(reservationEntity.Status),
+		Reference:  reservationEntity.Reference,
+		ExpiresAt:  reservationEntity.ExpiresAt,
+		CreatedAt:  reservationEntity.CreatedAt,
+		UpdatedAt:  reservationEntity.UpdatedAt,
+	}
+}
@@ -0,0 +1,250 @@
+package inventoryrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/service/buncrud"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// VariantAvailability is the stock of a variant that is not reserved, summed over every location.
+type VariantAvailability struct {
+	VariantId uuid.UUID `bun:"product_variant_id"`
+	Available int       `bun:"available"`
+}
+
+// Repository defines the data access layer for the Inventory aggregate.
+type Repository interface {
+	// WithTx returns a new repository instance that uses the provided transaction.
+	WithTx(ctx context.Context, tx bun.Tx) Repository
+
+	// Location returns a repository scoped to the InventoryLocation entity.
+	Location() buncrud.BaseRepository[masterdataentity.InventoryLocation]
+
+	// Stock returns a repository scoped to the InventoryStock entity.
+	Stock() buncrud.BaseRepository[masterdataentity.InventoryStock]
+
+	// Movement returns a repository scoped to the InventoryMovement ledger.
+	Movement() buncrud.BaseRepository[masterdataentity.InventoryMovement]
+
+	// Reservation returns a repository scoped to the InventoryReservation entity.
+	Reservation() buncrud.BaseRepository[masterdataentity.InventoryReservation]
+
+	// EnsureStock creates an empty stock row for the variant at the location if there is none yet.
+	EnsureStock(ctx context.Context, variantId uuid.UUID, locationId uuid.UUID) error
+
+	// ApplyStockChange atomically adds the deltas to the stock of the variant at the location.
+	// The change is only applied when the stock on hand still covers the reserved stock afterwards,
+	// otherwise crud.ErrNotFound is returned.
+	ApplyStockChange(ctx context.Context, variantId uuid.UUID, locationId uuid.UUID, onHandDelta int, reservedDelta int) (*masterdataentity.InventoryStock, error)
+
+	// TransitionReservation atomically moves a reservation from one status to another. When unexpired is set, only a
+	// reservation that has not expired yet is moved. It returns crud.ErrNotFound when the reservation is not in the
+	// expected status anymore, or has expired.
+	TransitionReservation(ctx context.Context, id uuid.UUID, from string, to string, unexpired bool) (*masterdataentity.InventoryReservation, error)
+
+	// FindExpiredReservationIds returns the ids of the pending reservations that expired before the given time.
+	FindExpiredReservationIds(ctx context.Context, status string, before time.Time, limit int) ([]uuid.UUID, error)
+
+	// FindAvailabilityIn returns the available stock of the given variants. Variants without stock are omitted.
+	FindAvailabilityIn(ctx context.Context, variantIds []uuid.UUID) ([]*VariantAvailability, error)
+}
+
+// RepositoryModule is the implementation of the Repository interface.
+type RepositoryModule struct {
+	locationsRepo    buncrud.BaseRepository[masterdataentity.InventoryLocation]
+	stocksRepo       buncrud.BaseRepository[masterdataentity.InventoryStock]
+	movementsRepo    buncrud.BaseRepository[masterdataentity.InventoryMovement]
+	reservationsRepo buncrud.BaseRepository[masterdataentity.InventoryReservation]
+	db               bun.IDB // Can be *bun.DB or *bun.Tx
+}
+
+type RepositoryOpts struct {
+	Bun *bun.DB
+}
+
+// NewRepository creates a new repository for the Inventory aggregate.
+func NewRepository(opts RepositoryOpts) Repository {
+	return &RepositoryModule{
+		locationsRepo:    buncrud.NewBaseRepository[masterdataentity.InventoryLocation](opts.Bun),
+		stocksRepo:       buncrud.NewBaseRepository[masterdataentity.InventoryStock](opts.Bun),
+		movementsRepo:    buncrud.NewBaseRepository[masterdataentity.InventoryMovement](opts.Bun),
+		reservationsRepo: buncrud.NewBaseRepository[masterdataentity.InventoryReservation](opts.Bun),
+		db:               opts.Bun,
+	}
+}
+
+// WithTx returns a new repository instance that uses the provided transaction.
+func (r *RepositoryModule) WithTx(ctx context.Context, tx bun.Tx) Repository {
+	return &RepositoryModule{
+		locationsRepo:    r.locationsRepo.WithTx(ctx, tx),
+		stocksRepo:       r.stocksRepo.WithTx(ctx, tx),
+		movementsRepo:    r.movementsRepo.WithTx(ctx, tx),
+		reservationsRepo: r.reservationsRepo.WithTx(ctx, tx),
+		db:               tx,
+	}
+}
+
+func (r *RepositoryModule) Location() buncrud.BaseRepository[masterdataentity.InventoryLocation] {
+	return r.locationsRepo
+}
+
+func (r *RepositoryModule) Stock() buncrud.BaseRepository[masterdataentity.InventoryStock] {
+	return r.stocksRepo
+}
+
+func (r *RepositoryModule) Movement() buncrud.BaseRepository[masterdataentity.InventoryMovement] {
+	return r.movementsRepo
+}
+
+func (r *RepositoryModule) Reservation() buncrud.BaseRepository[masterdataentity.InventoryReservation] {
+	return r.reservationsRepo
+}
+
+func (r *RepositoryModule) EnsureStock(ctx context.Context, variantId uuid.UUID, locationId uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "InventoryRepository/EnsureStock", map[string]any{
+		"variantId":  variantId,
+		"locationId": locationId,
+	})
+	defer span.End()
+
+	stock := &masterdataentity.InventoryStock{
+		Id:         uuid.New(),
+		VariantId:  variantId,
+		LocationId: locationId,
+	}
+
+	_, err := r.db.NewInsert().
+		Model(stock).
+		On("CONFLICT (product_variant_id, location_id) DO NOTHING").
+		Exec(ctx)
+
+	return err
+}
+
+func (r *RepositoryModule) ApplyStockChange(ctx context.Context, variantId uuid.UUID, locationId uuid.UUID, onHandDelta int, reservedDelta int) (*masterdataentity.InventoryStock, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "InventoryRepository/ApplyStockChange", map[string]any{
+		"variantId":     variantId,
+		"locationId":    locationId,
+		"onHandDelta":   onHandDelta,
+		"reservedDelta": reservedDelta,
+	})
+	defer span.End()
+
+	stock := &masterdataentity.InventoryStock{}
+
+	// The conditions are evaluated against the locked row, so concurrent changes can not oversell.
+	_, err := r.db.NewUpdate().
+		Model(stock).
+		Set("on_hand = on_hand + ?", onHandDelta).
+		Set("reserved = reserved + ?", reservedDelta).
+		Set("updated_at = NOW()").
+		Where("product_variant_id = ?", variantId).
+		Where("location_id = ?", locationId).
+		Where("reserved + ? >= 0", reservedDelta).
+		Where("on_hand + ? >= reserved + ?", onHandDelta, reservedDelta).
+		Returning("*").
+		Exec(ctx, stock)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, crud.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if stock.Id == uuid.Nil {
+		return nil, crud.ErrNotFound
+	}
+
+	return stock, nil
+}
+
+func (r *RepositoryModule) TransitionReservation(ctx context.Context, id uuid.UUID, from string, to string, unexpired bool) (*masterdataentity.InventoryReservation, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "InventoryRepository/TransitionReservation", map[string]any{
+		"id":        id,
+		"from":      from,
+		"to":        to,
+		"unexpired": unexpired,
+	})
+	defer span.End()
+
+	reservation := &masterdataentity.InventoryReservation{}
+
+	query := r.db.NewUpdate().
+		Model(reservation).
+		Set("status = ?", to).
+		Set("updated_at = NOW()").
+		Where("id = ?", id).
+		Where("status = ?", from)
+	if unexpired {
+		query = query.Where("expires_at > NOW()")
+	}
+
+	_, err := query.
+		Returning("*").
+		Exec(ctx, reservation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, crud.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if reservation.Id == uuid.Nil {
+		return nil, crud.ErrNotFound
+	}
+
+	return reservation, nil
+}
+
+func (r *RepositoryModule) FindExpiredReservationIds(ctx context.Context, status string, before time.Time, limit int) ([]uuid.UUID, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "InventoryRepository/FindExpiredReservationIds", map[string]any{
+		"status": status,
+		"before": before,
+		"limit":  limit,
+	})
+	defer span.End()
+
+	var ids []uuid.UUID
+
+	err := r.db.NewSelect().
+		Model((*masterdataentity.InventoryReservation)(nil)).
+		Column("id").
+		Where("status = ?", status).
+		Where("expires_at <= ?", before).
+		OrderExpr("expires_at ASC").
+		Limit(limit).
+		Scan(ctx, &ids)
+
+	return ids, err
+}
+
+func (r *RepositoryModule) FindAvailabilityIn(ctx context.Context, variantIds []uuid.UUID) ([]*VariantAvailability, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "InventoryRepository/FindAvailabilityIn", map[string]any{
+		"variantIds": variantIds,
+	})
+	defer span.End()
+
+	var availabilities []*VariantAvailability
+	if len(variantIds) == 0 {
+		return availabilities, nil
+	}
+
+	err := r.db.NewSelect().
+		Model((*masterdataentity.InventoryStock)(nil)).
+		Column("product_variant_id").
+		ColumnExpr("SUM(on_hand - reserved) AS available").
+		Where("product_variant_id IN (?)", bun.In(variantIds)).
+		Group("product_variant_id").
+		Scan(ctx, &availabilities)
+
+	return availabilities, err
+}
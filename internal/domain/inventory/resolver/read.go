@@ -0,0 +1,25 @@
+package inventoryresolver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	inventorydto "gobase/internal/domain/inventory/dto"
+)
+
+func (r *ResolverModule) FindLocations(ctx context.Context) ([]*inventorydto.InventoryLocation, error) {
+	return r.inventoryUseCase.FindLocations(ctx)
+}
+
+func (r *ResolverModule) FindStocks(ctx context.Context, variantId uuid.UUID) ([]*inventorydto.InventoryStock, error) {
+	return r.inventoryUseCase.FindStocks(ctx, variantId)
+}
+
+func (r *ResolverModule) FindMovements(ctx context.Context, qop *inventorydto.InventoryMovementQop) (*inventorydto.InventoryMovementList, error) {
+	return r.inventoryUseCase.FindMovements(ctx, qop)
+}
+
+func (r *ResolverModule) FindReservationById(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	return r.inventoryUseCase.FindReservationById(ctx, id)
+}
@@ -0,0 +1,40 @@
+package inventoryresolver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	inventorydto "gobase/internal/domain/inventory/dto"
+	inventoryusecase "gobase/internal/domain/inventory/usecase"
+)
+
+// Resolver is the interface for the inventory domain's GraphQL queries and mutations.
+type Resolver interface {
+	CreateLocation(ctx context.Context, input inventorydto.CreateInventoryLocationInput) (*inventorydto.InventoryLocation, error)
+	AdjustStock(ctx context.Context, input inventorydto.AdjustStockInput) (*inventorydto.InventoryStock, error)
+	ReserveStock(ctx context.Context, input inventorydto.ReserveStockInput) (*inventorydto.InventoryReservation, error)
+	ReleaseReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error)
+	CommitReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error)
+	FindLocations(ctx context.Context) ([]*inventorydto.InventoryLocation, error)
+	FindStocks(ctx context.Context, variantId uuid.UUID) ([]*inventorydto.InventoryStock, error)
+	FindMovements(ctx context.Context, qop *inventorydto.InventoryMovementQop) (*inventorydto.InventoryMovementList, error)
+	FindReservationById(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error)
+}
+
+// ResolverModule is the implementation of the Resolver interface.
+type ResolverModule struct {
+	inventoryUseCase inventoryusecase.UseCase
+}
+
+// ResolverOptions holds the dependencies needed to create a new resolver.
+type ResolverOptions struct {
+	InventoryUseCase inventoryusecase.UseCase
+}
+
+// NewResolver creates a new, configured inventory resolver.
+func NewResolver(opts ResolverOptions) Resolver {
+	return &ResolverModule{
+		inventoryUseCase: opts.InventoryUseCase,
+	}
+}
@@ -0,0 +1,29 @@
+package inventoryresolver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	inventorydto "gobase/internal/domain/inventory/dto"
+)
+
+func (r *ResolverModule) CreateLocation(ctx context.Context, input inventorydto.CreateInventoryLocationInput) (*inventorydto.InventoryLocation, error) {
+	return r.inventoryUseCase.CreateLocation(ctx, input)
+}
+
+func (r *ResolverModule) AdjustStock(ctx context.Context, input inventorydto.AdjustStockInput) (*inventorydto.InventoryStock, error) {
+	return r.inventoryUseCase.AdjustStock(ctx, input)
+}
+
+func (r *ResolverModule) ReserveStock(ctx context.Context, input inventorydto.ReserveStockInput) (*inventorydto.InventoryReservation, error) {
+	return r.inventoryUseCase.ReserveStock(ctx, input)
+}
+
+func (r *ResolverModule) ReleaseReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	return r.inventoryUseCase.ReleaseReservation(ctx, id)
+}
+
+func (r *ResolverModule) CommitReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	return r.inventoryUseCase.CommitReservation(ctx, id)
+}
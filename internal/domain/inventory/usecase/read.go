@@ -0,0 +1,91 @@
+package inventoryusecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	inventorydto "gobase/internal/domain/inventory/dto"
+	inventorymapper "gobase/internal/domain/inventory/mapper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+func (m *UseCaseModule) FindLocations(ctx context.Context) ([]*inventorydto.InventoryLocation, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/FindLocations")
+	defer span.End()
+
+	options := &crud.QueryOptions{}
+	options.WithSort("code", "ASC")
+
+	entityResult, err := m.repository.Location().FindAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	locationDTOs := make([]*inventorydto.InventoryLocation, len(entityResult.Items))
+	for i, l := range entityResult.Items {
+		locationDTOs[i] = inventorymapper.InventoryLocationEntityToDTO(&l)
+	}
+
+	return locationDTOs, nil
+}
+
+func (m *UseCaseModule) FindStocks(ctx context.Context, variantId uuid.UUID) ([]*inventorydto.InventoryStock, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/FindStocks")
+	defer span.End()
+
+	stocks, err := m.repository.Stock().FindIn(ctx, "product_variant_id", []any{variantId}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(stocks, func(stock *masterdataentity.InventoryStock, _ int) *inventorydto.InventoryStock {
+		return inventorymapper.InventoryStockEntityToDTO(stock)
+	}), nil
+}
+
+func (m *UseCaseModule) FindMovements(ctx context.Context, qop *inventorydto.InventoryMovementQop) (*inventorydto.InventoryMovementList, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/FindMovements")
+	defer span.End()
+
+	options := crud.NewQueryOptions()
+
+	if qop != nil {
+		qop.WithAllowedSorts([]string{"created_at", "type"})
+		options = qop.ToQueryOptions()
+	}
+
+	if len(options.Sorts) == 0 {
+		options.WithSort("created_at", "DESC")
+	}
+
+	entityResult, err := m.repository.Movement().FindAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	movementDTOs := make([]*inventorydto.InventoryMovement, len(entityResult.Items))
+	for i, mv := range entityResult.Items {
+		movementDTOs[i] = inventorymapper.InventoryMovementEntityToDTO(&mv)
+	}
+
+	return &inventorydto.InventoryMovementList{
+		Items:      movementDTOs,
+		Pagination: entityResult.Pagination,
+	}, nil
+}
+
+func (m *UseCaseModule) FindReservationById(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/FindReservationById")
+	defer span.End()
+
+	reservationEntity, err := m.repository.Reservation().FindByID(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return inventorymapper.InventoryReservationEntityToDTO(reservationEntity), nil
+}
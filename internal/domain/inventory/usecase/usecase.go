@@ -0,0 +1,69 @@
+package inventoryusecase
+
+import (
+	"context"
+
+	"clodeo.tech/public/go-universe/pkg/localization"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"gobase/config"
+	inventorydto "gobase/internal/domain/inventory/dto"
+	inventoryeventpublisher "gobase/internal/domain/inventory/event/publisher"
+	inventoryrepository "gobase/internal/domain/inventory/repository"
+	productrepository "gobase/internal/domain/product/repository"
+	structprocessor "gobase/internal/pkg/service/structprocessor"
+)
+
+const (
+	// languageId is the language of the error messages returned by the inventory use case.
+	languageId = "id"
+
+	defaultReservationTTLSecond       = 900
+	defaultReservationExpiryBatchSize = 100
+)
+
+type UseCase interface {
+	CreateLocation(ctx context.Context, input inventorydto.CreateInventoryLocationInput) (*inventorydto.InventoryLocation, error)
+	AdjustStock(ctx context.Context, input inventorydto.AdjustStockInput) (*inventorydto.InventoryStock, error)
+	ReserveStock(ctx context.Context, input inventorydto.ReserveStockInput) (*inventorydto.InventoryReservation, error)
+	ReleaseReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error)
+	CommitReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error)
+	ExpireReservations(ctx context.Context) (int, error)
+	FindLocations(ctx context.Context) ([]*inventorydto.InventoryLocation, error)
+	FindStocks(ctx context.Context, variantId uuid.UUID) ([]*inventorydto.InventoryStock, error)
+	FindMovements(ctx context.Context, qop *inventorydto.InventoryMovementQop) (*inventorydto.InventoryMovementList, error)
+	FindReservationById(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error)
+}
+
+type UseCaseModule struct {
+	bun                     *bun.DB
+	cfg                     *config.MainConfig
+	repository              inventoryrepository.Repository
+	productRepository       productrepository.Repository
+	sp                      structprocessor.StructProcessorService
+	localizer               localization.Localizer
+	inventoryEventPublisher inventoryeventpublisher.Event
+}
+
+type UseCaseOpts struct {
+	Bun                     *bun.DB
+	Config                  *config.MainConfig
+	Repository              inventoryrepository.Repository
+	ProductRepository       productrepository.Repository
+	SP                      structprocessor.StructProcessorService
+	Localizer               localization.Localizer
+	InventoryEventPublisher inventoryeventpublisher.Event
+}
+
+func NewUseCase(opts UseCaseOpts) UseCase {
+	return &UseCaseModule{
+		bun:                     opts.Bun,
+		cfg:                     opts.Config,
+		repository:              opts.Repository,
+		productRepository:       opts.ProductRepository,
+		sp:                      opts.SP,
+		localizer:               opts.Localizer,
+		inventoryEventPublisher: opts.InventoryEventPublisher,
+	}
+}
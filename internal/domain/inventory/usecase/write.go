@@ -0,0 +1,338 @@
+package inventoryusecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	inventorydto "gobase/internal/domain/inventory/dto"
+	inventorymapper "gobase/internal/domain/inventory/mapper"
+	inventoryrepository "gobase/internal/domain/inventory/repository"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// stockChange describes a change of the stock of a variant at a location, to be recorded in the movement ledger.
+type stockChange struct {
+	variantId     uuid.UUID
+	locationId    uuid.UUID
+	reservationId uuid.UUID
+	movementType  inventorydto.InventoryMovementType
+	onHandDelta   int
+	reservedDelta int
+	reason        string
+}
+
+func (m *UseCaseModule) CreateLocation(ctx context.Context, input inventorydto.CreateInventoryLocationInput) (*inventorydto.InventoryLocation, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/CreateLocation")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	createdLocation, err := m.repository.Location().Create(ctx, input.ToEntity(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return inventorymapper.InventoryLocationEntityToDTO(createdLocation), nil
+}
+
+// AdjustStock adds to or removes from the stock on hand. Stock that is reserved can not be removed.
+func (m *UseCaseModule) AdjustStock(ctx context.Context, input inventorydto.AdjustStockInput) (*inventorydto.InventoryStock, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/AdjustStock")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.ensureVariantAndLocationExist(ctx, input.VariantID, input.LocationID); err != nil {
+		return nil, err
+	}
+
+	var stock *masterdataentity.InventoryStock
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		if err := txRepo.EnsureStock(ctx, input.VariantID, input.LocationID); err != nil {
+			return err
+		}
+
+		var movement *masterdataentity.InventoryMovement
+		stock, movement, err = m.applyStockChange(ctx, txRepo, stockChange{
+			variantId:     input.VariantID,
+			locationId:    input.LocationID,
+			movementType:  inventorydto.InventoryMovementTypeAdjustment,
+			onHandDelta:   input.Quantity,
+			reservedDelta: 0,
+			reason:        input.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return m.inventoryEventPublisher.PublishStockAdjusted(ctx, tx, movement)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return inventorymapper.InventoryStockEntityToDTO(stock), nil
+}
+
+// ReserveStock holds stock for a pending order or cart until the reservation is committed, released or expired.
+func (m *UseCaseModule) ReserveStock(ctx context.Context, input inventorydto.ReserveStockInput) (*inventorydto.InventoryReservation, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/ReserveStock")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.ensureVariantAndLocationExist(ctx, input.VariantID, input.LocationID); err != nil {
+		return nil, err
+	}
+
+	ttlSecond := m.cfg.Inventory.ReservationTTLSecond
+	if input.TTLSeconds != nil {
+		ttlSecond = *input.TTLSeconds
+	}
+	if ttlSecond <= 0 {
+		ttlSecond = defaultReservationTTLSecond
+	}
+
+	reservation := &masterdataentity.InventoryReservation{
+		Id:         uuid.New(),
+		VariantId:  input.VariantID,
+		LocationId: input.LocationID,
+		Quantity:   input.Quantity,
+		Status:     string(inventorydto.InventoryReservationStatusPending),
+		Reference:  input.Reference,
+		ExpiresAt:  time.Now().Add(time.Duration(ttlSecond) * time.Second),
+	}
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		if err := txRepo.EnsureStock(ctx, input.VariantID, input.LocationID); err != nil {
+			return err
+		}
+
+		_, movement, err := m.applyStockChange(ctx, txRepo, stockChange{
+			variantId:     input.VariantID,
+			locationId:    input.LocationID,
+			reservationId: reservation.Id,
+			movementType:  inventorydto.InventoryMovementTypeReserve,
+			onHandDelta:   0,
+			reservedDelta: input.Quantity,
+			reason:        input.Reference,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := txRepo.Reservation().Create(ctx, reservation); err != nil {
+			return err
+		}
+
+		return m.inventoryEventPublisher.PublishStockReserved(ctx, tx, movement)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return inventorymapper.InventoryReservationEntityToDTO(reservation), nil
+}
+
+// ReleaseReservation gives the stock of a pending reservation back.
+func (m *UseCaseModule) ReleaseReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/ReleaseReservation")
+	defer span.End()
+
+	return m.closeReservation(ctx, id, inventorydto.InventoryReservationStatusReleased)
+}
+
+// CommitReservation takes the stock of a pending reservation out of the stock on hand.
+func (m *UseCaseModule) CommitReservation(ctx context.Context, id uuid.UUID) (*inventorydto.InventoryReservation, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/CommitReservation")
+	defer span.End()
+
+	return m.closeReservation(ctx, id, inventorydto.InventoryReservationStatusCommitted)
+}
+
+// ExpireReservations releases the stock of the pending reservations that outlived their expiry, and returns
+// how many of them were expired. Reservations that are closed concurrently are skipped.
+func (m *UseCaseModule) ExpireReservations(ctx context.Context) (int, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "InventoryUseCase/ExpireReservations")
+	defer span.End()
+
+	batchSize := m.cfg.Inventory.ReservationExpiryBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReservationExpiryBatchSize
+	}
+
+	ids, err := m.repository.FindExpiredReservationIds(ctx, string(inventorydto.InventoryReservationStatusPending), time.Now(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		_, err := m.closeReservation(ctx, id, inventorydto.InventoryReservationStatusExpired)
+		if err != nil {
+			log.Warn().Err(err).Str("reservationId", id.String()).Msg("failed to expire inventory reservation")
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// closeReservation moves a pending reservation to its final status and applies the matching stock change.
+func (m *UseCaseModule) closeReservation(ctx context.Context, id uuid.UUID, status inventorydto.InventoryReservationStatus) (*inventorydto.InventoryReservation, error) {
+	var reservation *masterdataentity.InventoryReservation
+
+	err := m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		// A lapsed hold can not be committed anymore, even before the expiry job releases it.
+		unexpired := status == inventorydto.InventoryReservationStatusCommitted
+
+		var err error
+		reservation, err = txRepo.TransitionReservation(ctx, id, string(inventorydto.InventoryReservationStatusPending), string(status), unexpired)
+		if err != nil {
+			if errors.Is(err, crud.ErrNotFound) {
+				return m.reservationNotClosable(ctx, txRepo, id)
+			}
+			return err
+		}
+
+		change := stockChange{
+			variantId:     reservation.VariantId,
+			locationId:    reservation.LocationId,
+			reservationId: reservation.Id,
+			reservedDelta: -reservation.Quantity,
+			reason:        reservation.Reference,
+		}
+
+		var publish func(ctx context.Context, movement *masterdataentity.InventoryMovement) error
+		switch status {
+		case inventorydto.InventoryReservationStatusCommitted:
+			change.movementType = inventorydto.InventoryMovementTypeCommit
+			change.onHandDelta = -reservation.Quantity
+			publish = func(ctx context.Context, movement *masterdataentity.InventoryMovement) error {
+				return m.inventoryEventPublisher.PublishReservationCommitted(ctx, tx, movement)
+			}
+		case inventorydto.InventoryReservationStatusExpired:
+			change.movementType = inventorydto.InventoryMovementTypeExpire
+			publish = func(ctx context.Context, movement *masterdataentity.InventoryMovement) error {
+				return m.inventoryEventPublisher.PublishReservationExpired(ctx, tx, movement)
+			}
+		default:
+			change.movementType = inventorydto.InventoryMovementTypeRelease
+			publish = func(ctx context.Context, movement *masterdataentity.InventoryMovement) error {
+				return m.inventoryEventPublisher.PublishReservationReleased(ctx, tx, movement)
+			}
+		}
+
+		_, movement, err := m.applyStockChange(ctx, txRepo, change)
+		if err != nil {
+			return err
+		}
+
+		return publish(ctx, movement)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return inventorymapper.InventoryReservationEntityToDTO(reservation), nil
+}
+
+// applyStockChange applies a stock change and appends it to the movement ledger. It must be called inside a transaction.
+func (m *UseCaseModule) applyStockChange(ctx context.Context, txRepo inventoryrepository.Repository, change stockChange) (*masterdataentity.InventoryStock, *masterdataentity.InventoryMovement, error) {
+	stock, err := txRepo.ApplyStockChange(ctx, change.variantId, change.locationId, change.onHandDelta, change.reservedDelta)
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorInsufficientStock", nil)
+		}
+		return nil, nil, err
+	}
+
+	movement := &masterdataentity.InventoryMovement{
+		Id:            uuid.New(),
+		VariantId:     change.variantId,
+		LocationId:    change.locationId,
+		ReservationId: change.reservationId,
+		Type:          string(change.movementType),
+		OnHandDelta:   change.onHandDelta,
+		ReservedDelta: change.reservedDelta,
+		OnHandAfter:   stock.OnHand,
+		ReservedAfter: stock.Reserved,
+		Reason:        change.reason,
+	}
+
+	if _, err := txRepo.Movement().Create(ctx, movement); err != nil {
+		return nil, nil, err
+	}
+
+	return stock, movement, nil
+}
+
+// reservationNotClosable tells why a reservation could not be closed: it does not exist, it is not pending anymore,
+// or it is still pending but its hold expired before it was committed.
+func (m *UseCaseModule) reservationNotClosable(ctx context.Context, repo inventoryrepository.Repository, id uuid.UUID) error {
+	reservation, err := repo.Reservation().FindByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "InventoryReservation")
+		}
+		return err
+	}
+
+	if reservation.Status == string(inventorydto.InventoryReservationStatusPending) {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorInventoryReservationExpired", nil)
+	}
+	return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorInventoryReservationNotPending", nil)
+}
+
+func (m *UseCaseModule) ensureVariantAndLocationExist(ctx context.Context, variantId uuid.UUID, locationId uuid.UUID) error {
+	exists, err := m.productRepository.Variant().Exists(ctx, variantId.String())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductVariant")
+	}
+
+	exists, err = m.repository.Location().Exists(ctx, locationId.String())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "InventoryLocation")
+	}
+
+	return nil
+}
@@ -1,41 +1,187 @@
 package productloader
 
 import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	dataloader "github.com/graph-gophers/dataloader/v7"
+	"github.com/samber/lo"
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
 	productdto "gobase/internal/domain/product/dto"
 	productmapper "gobase/internal/domain/product/mapper"
 	productrepo "gobase/internal/domain/product/repository"
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/service/crud"
 	"gobase/internal/pkg/service/gqldataloader"
 )
 
 // Dataloader holds all the dataloaders for the product domain.
 type Dataloader struct {
-	Variant        *dataloader.Loader[uuid.UUID, []*productdto.ProductVariant]
-	AttributeValue *dataloader.Loader[uuid.UUID, []*productdto.ProductAttributeValue]
-	Attribute      *dataloader.Loader[uuid.UUID, []*productdto.ProductAttribute]
+	Variant         *dataloader.Loader[gqldataloader.PageKey[uuid.UUID], []*productdto.ProductVariant]
+	AttributeValue  *dataloader.Loader[uuid.UUID, []*productdto.ProductAttributeValue]
+	Attribute       *dataloader.Loader[uuid.UUID, *productdto.ProductAttribute]
+	VariantPrice    *dataloader.Loader[uuid.UUID, []*productdto.ProductVariantPrice]
+	PriceHistory    *dataloader.Loader[uuid.UUID, []*productdto.ProductVariantPriceHistory]
+	EffectivePrice  *dataloader.Loader[EffectivePriceKey, *productdto.ProductVariantPriceHistory]
+	Translation     *dataloader.Loader[uuid.UUID, []*productdto.ProductTranslation]
+	Highlight       *dataloader.Loader[HighlightKey, *productdto.ProductSearchHighlight]
+	VariantByID     *dataloader.Loader[uuid.UUID, *productdto.ProductVariant]
+	Bundle          *dataloader.Loader[uuid.UUID, *productdto.ProductBundle]
+	BundleComponent *dataloader.Loader[uuid.UUID, []*productdto.ProductBundleComponent]
+	Media           *dataloader.Loader[uuid.UUID, []*productdto.ProductMedia]
+	Tag             *dataloader.Loader[uuid.UUID, []*productdto.ProductTag]
+	RelatedProducts *dataloader.Loader[RelatedProductsKey, []*productdto.Product]
+}
+
+// HighlightKey identifies the highlighted content of a product for a full-text search in a language.
+type HighlightKey struct {
+	ProductID uuid.UUID
+	Search    string
+	Language  string
+}
+
+// EffectivePriceKey identifies the scheduled price of a variant at a point in time.
+type EffectivePriceKey struct {
+	VariantID uuid.UUID
+	At        time.Time
+}
+
+// NewEffectivePriceKey creates a key for the EffectivePrice loader. The time is normalized so that
+// keys for the same instant are equal.
+func NewEffectivePriceKey(variantId uuid.UUID, at time.Time) EffectivePriceKey {
+	return EffectivePriceKey{
+		VariantID: variantId,
+		At:        at.UTC().Truncate(time.Microsecond),
+	}
+}
+
+// DefaultRelatedProductsLimit and MaxRelatedProductsLimit bound the number of related products loaded for a product.
+const (
+	DefaultRelatedProductsLimit = 5
+	MaxRelatedProductsLimit     = 20
+)
+
+// RelatedProductsKey identifies the related products of a product for a ranking and a language.
+type RelatedProductsKey struct {
+	ProductID uuid.UUID
+	relatedProductsGroup
+}
+
+// relatedProductsGroup holds the parameters of a RelatedProductsKey that are shared by the keys loaded in one query.
+type relatedProductsGroup struct {
+	Limit          int
+	CategoryWeight float64
+	TagWeight      float64
+	Language       string
+}
+
+// NewRelatedProductsKey creates a key for the RelatedProducts loader. The limit defaults to 5 and is capped at 20,
+// and negative weights are ignored.
+func NewRelatedProductsKey(productId uuid.UUID, limit *int, categoryWeight *float64, tagWeight *float64, lang string) RelatedProductsKey {
+	size := DefaultRelatedProductsLimit
+	if limit != nil && *limit > 0 {
+		size = min(*limit, MaxRelatedProductsLimit)
+	}
+
+	return RelatedProductsKey{
+		ProductID: productId,
+		relatedProductsGroup: relatedProductsGroup{
+			Limit:          size,
+			CategoryWeight: max(lo.FromPtr(categoryWeight), 0),
+			TagWeight:      max(lo.FromPtr(tagWeight), 0),
+			Language:       lang,
+		},
+	}
 }
 
-// NewDataloader creates a new set of dataloaders for the product domain.
-func NewDataloader(productRepo productrepo.Repository) *Dataloader {
+// MaxVariantsFirst is the maximum and the default number of variants loaded for a product.
+const MaxVariantsFirst = 100
+
+// variantSorts are the fields the variants of a product can be sorted on.
+var variantSorts = []string{"sku", "price", "created_at"}
+
+// NewVariantsKey creates a key for the Variant loader from the arguments of the variants field. All the variants are
+// loaded unless first is given, which is capped at 100. The sorts and filter are encoded in the key so that the
+// variants loaded for different arguments do not collide.
+func NewVariantsKey(productId uuid.UUID, first *int, sorts []*crud.Sort, filter *productdto.ProductVariantQopFilter) (gqldataloader.PageKey[uuid.UUID], error) {
+	qop := productdto.ProductVariantQop{
+		Sorts: lo.FilterMap(sorts, func(s *crud.Sort, _ int) (crud.Sort, bool) {
+			if s == nil {
+				return crud.Sort{}, false
+			}
+			// The direction is case insensitive, so it is upper cased for equal sorts to share a batch.
+			return crud.Sort{Field: s.Field, Direction: strings.ToUpper(s.Direction)}, true
+		}),
+	}
+	if filter != nil {
+		qop.Filters = *filter
+		if qop.Filters.Currency != nil {
+			qop.Filters.Currency = lo.ToPtr(strings.ToUpper(*qop.Filters.Currency))
+		}
+	}
+	qop.WithAllowedSorts(variantSorts)
+
+	args, err := json.Marshal(qop)
+	if err != nil {
+		return gqldataloader.PageKey[uuid.UUID]{}, err
+	}
+
+	size := MaxVariantsFirst
+	if first != nil && *first > 0 {
+		size = min(*first, MaxVariantsFirst)
+	}
+
+	return gqldataloader.PageKey[uuid.UUID]{
+		ParentID: productId,
+		First:    size,
+		Args:     string(args),
+	}, nil
+}
+
+// NewDataloader creates a new set of dataloaders for the product domain. They are meant to be created per request.
+func NewDataloader(productRepo productrepo.Repository, opts gqldataloader.Options) *Dataloader {
 	return &Dataloader{
-		Variant:        dataloader.NewBatchedLoader(newVariantBatchFn(productRepo)),
-		AttributeValue: dataloader.NewBatchedLoader(newAttributeValueBatchFn(productRepo)),
-		Attribute:      dataloader.NewBatchedLoader(newAttributeBatchFn(productRepo)),
+		Variant:         gqldataloader.NewLoader(newVariantBatchFn(productRepo), opts),
+		AttributeValue:  gqldataloader.NewLoader(newAttributeValueBatchFn(productRepo), opts),
+		Attribute:       gqldataloader.NewLoader(newAttributeBatchFn(productRepo), opts),
+		VariantPrice:    gqldataloader.NewLoader(newVariantPriceBatchFn(productRepo), opts),
+		PriceHistory:    gqldataloader.NewLoader(newPriceHistoryBatchFn(productRepo), opts),
+		EffectivePrice:  gqldataloader.NewLoader(newEffectivePriceBatchFn(productRepo), opts),
+		Translation:     gqldataloader.NewLoader(newTranslationBatchFn(productRepo), opts),
+		Highlight:       gqldataloader.NewLoader(newHighlightBatchFn(productRepo), opts),
+		VariantByID:     gqldataloader.NewLoader(newVariantByIDBatchFn(productRepo), opts),
+		Bundle:          gqldataloader.NewLoader(newBundleBatchFn(productRepo), opts),
+		BundleComponent: gqldataloader.NewLoader(newBundleComponentBatchFn(productRepo), opts),
+		Media:           gqldataloader.NewLoader(newMediaBatchFn(productRepo), opts),
+		Tag:             gqldataloader.NewLoader(newTagBatchFn(productRepo), opts),
+		RelatedProducts: gqldataloader.NewLoader(newRelatedProductsBatchFn(productRepo), opts),
 	}
 }
 
-// newVariantBatchFn creates a batch function for loading product variants using the generic batch function.
-func newVariantBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductVariant] {
-	return gqldataloader.NewGenericBatchFn(
+// newVariantBatchFn creates a batch function for loading the first variants of products using the generic page
+// batch function. Keys are loaded in one query per distinct arguments, oldest variant first unless sorted.
+func newVariantBatchFn(repo productrepo.Repository) dataloader.BatchFunc[gqldataloader.PageKey[uuid.UUID], []*productdto.ProductVariant] {
+	return gqldataloader.NewGenericPageBatchFn(
 		repo.Variant(),
-		[]string{"product_id"},
+		"product_id",
 		func(item *masterdataentity.ProductVariant) uuid.UUID {
 			return item.ProductId
 		},
-		nil,
+		func(args string) (*crud.QueryOptions, error) {
+			var qop productdto.ProductVariantQop
+			if err := json.Unmarshal([]byte(args), &qop); err != nil {
+				return nil, err
+			}
+
+			options := qop.WithAllowedSorts(variantSorts).ToQueryOptions()
+			options.Sorts = append(options.Sorts, crud.Sort{Field: "created_at", Direction: "ASC"}, crud.Sort{Field: "id", Direction: "ASC"})
+			return options, nil
+		},
 		productmapper.ProductVariantEntityToDTO,
 	)
 }
@@ -53,9 +199,9 @@ func newAttributeValueBatchFn(repo productrepo.Repository) dataloader.BatchFunc[
 	)
 }
 
-// newAttributeBatchFn creates a batch function for loading product attribute values using the generic batch function.
-func newAttributeBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductAttribute] {
-	return gqldataloader.NewGenericBatchFn(
+// newAttributeBatchFn creates a batch function for loading product attributes by their id using the generic one batch function.
+func newAttributeBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, *productdto.ProductAttribute] {
+	return gqldataloader.NewGenericOneBatchFn(
 		repo.Attribute(),
 		[]string{"id"},
 		func(item *masterdataentity.ProductAttribute) uuid.UUID {
@@ -63,5 +209,294 @@ func newAttributeBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.
 		},
 		nil,
 		productmapper.ProductAttributeEntityToDTO,
+		nil,
+	)
+}
+
+// newVariantPriceBatchFn creates a batch function for loading the price lists of product variants using the generic batch function.
+func newVariantPriceBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductVariantPrice] {
+	return gqldataloader.NewGenericBatchFn(
+		repo.VariantPrice(),
+		[]string{"product_variant_id"},
+		func(item *masterdataentity.ProductVariantPrice) uuid.UUID {
+			return item.VariantId
+		},
+		nil,
+		productmapper.ProductVariantPriceEntityToDTO,
+	)
+}
+
+// newPriceHistoryBatchFn creates a batch function for loading the scheduled prices of variants, latest window first.
+func newPriceHistoryBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductVariantPriceHistory] {
+	batchFn := gqldataloader.NewGenericBatchFn(
+		repo.VariantPriceHistory(),
+		[]string{"product_variant_id"},
+		func(item *masterdataentity.ProductVariantPriceHistory) uuid.UUID {
+			return item.VariantId
+		},
+		nil,
+		productmapper.ProductVariantPriceHistoryEntityToDTO,
+	)
+
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[[]*productdto.ProductVariantPriceHistory] {
+		results := batchFn(ctx, keys)
+		for _, result := range results {
+			sort.SliceStable(result.Data, func(i, j int) bool {
+				return result.Data[i].ValidFrom.After(result.Data[j].ValidFrom)
+			})
+		}
+		return results
+	}
+}
+
+// newEffectivePriceBatchFn creates a batch function for loading the scheduled price of variants at a point in time.
+// The result is nil for a variant without a window at that time. Keys are queried once per distinct time.
+func newEffectivePriceBatchFn(repo productrepo.Repository) dataloader.BatchFunc[EffectivePriceKey, *productdto.ProductVariantPriceHistory] {
+	return func(ctx context.Context, keys []EffectivePriceKey) []*dataloader.Result[*productdto.ProductVariantPriceHistory] {
+		results := make([]*dataloader.Result[*productdto.ProductVariantPriceHistory], len(keys))
+
+		keysByTime := lo.GroupBy(keys, func(key EffectivePriceKey) time.Time {
+			return key.At
+		})
+
+		histories := make(map[EffectivePriceKey]*productdto.ProductVariantPriceHistory, len(keys))
+		for at, timeKeys := range keysByTime {
+			variantIds := lo.Uniq(lo.Map(timeKeys, func(key EffectivePriceKey, _ int) uuid.UUID {
+				return key.VariantID
+			}))
+
+			items, err := repo.FindEffectivePricesIn(ctx, variantIds, at)
+			if err != nil {
+				for i := range keys {
+					results[i] = &dataloader.Result[*productdto.ProductVariantPriceHistory]{Error: err}
+				}
+				return results
+			}
+
+			for _, item := range items {
+				histories[EffectivePriceKey{VariantID: item.VariantId, At: at}] = productmapper.ProductVariantPriceHistoryEntityToDTO(item)
+			}
+		}
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result[*productdto.ProductVariantPriceHistory]{Data: histories[key]}
+		}
+
+		return results
+	}
+}
+
+// newTranslationBatchFn creates a batch function for loading the translations of products using the generic batch function.
+func newTranslationBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductTranslation] {
+	return gqldataloader.NewGenericBatchFn(
+		repo.Translation(),
+		[]string{"product_id"},
+		func(item *masterdataentity.ProductTranslation) uuid.UUID {
+			return item.ProductId
+		},
+		nil,
+		productmapper.ProductTranslationEntityToDTO,
+	)
+}
+
+// newHighlightBatchFn creates a batch function for loading the highlighted content of products.
+// Keys are queried once per distinct search and language.
+func newHighlightBatchFn(repo productrepo.Repository) dataloader.BatchFunc[HighlightKey, *productdto.ProductSearchHighlight] {
+	return func(ctx context.Context, keys []HighlightKey) []*dataloader.Result[*productdto.ProductSearchHighlight] {
+		results := make([]*dataloader.Result[*productdto.ProductSearchHighlight], len(keys))
+
+		type searchKey struct {
+			search   string
+			language string
+		}
+		keysBySearch := lo.GroupBy(keys, func(key HighlightKey) searchKey {
+			return searchKey{search: key.Search, language: key.Language}
+		})
+
+		highlights := make(map[HighlightKey]*productdto.ProductSearchHighlight, len(keys))
+		for search, searchKeys := range keysBySearch {
+			productIds := lo.Uniq(lo.Map(searchKeys, func(key HighlightKey, _ int) uuid.UUID {
+				return key.ProductID
+			}))
+
+			items, err := repo.FindSearchHighlightsIn(ctx, productIds, search.search, search.language)
+			if err != nil {
+				for i := range keys {
+					results[i] = &dataloader.Result[*productdto.ProductSearchHighlight]{Error: err}
+				}
+				return results
+			}
+
+			for _, item := range items {
+				key := HighlightKey{ProductID: item.ProductId, Search: search.search, Language: search.language}
+				highlights[key] = &productdto.ProductSearchHighlight{
+					Name:        item.Name,
+					Description: item.Description,
+				}
+			}
+		}
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result[*productdto.ProductSearchHighlight]{Data: highlights[key]}
+		}
+
+		return results
+	}
+}
+
+// newVariantByIDBatchFn creates a batch function for loading product variants by their id using the generic one batch function.
+func newVariantByIDBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, *productdto.ProductVariant] {
+	return gqldataloader.NewGenericOneBatchFn(
+		repo.Variant(),
+		[]string{"id"},
+		func(item *masterdataentity.ProductVariant) uuid.UUID {
+			return item.Id
+		},
+		nil,
+		productmapper.ProductVariantEntityToDTO,
+		nil,
+	)
+}
+
+// newBundleBatchFn creates a batch function for loading the bundle sold as a product variant using the generic one batch function.
+func newBundleBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, *productdto.ProductBundle] {
+	return gqldataloader.NewGenericOneBatchFn(
+		repo.Bundle(),
+		[]string{"product_variant_id"},
+		func(item *masterdataentity.ProductBundle) uuid.UUID {
+			return item.VariantId
+		},
+		nil,
+		productmapper.ProductBundleEntityToDTO,
+		nil,
+	)
+}
+
+// newBundleComponentBatchFn creates a batch function for loading the components of bundles using the generic batch function.
+func newBundleComponentBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductBundleComponent] {
+	return gqldataloader.NewGenericBatchFn(
+		repo.BundleComponent(),
+		[]string{"product_bundle_id"},
+		func(item *masterdataentity.ProductBundleComponent) uuid.UUID {
+			return item.BundleId
+		},
+		nil,
+		productmapper.ProductBundleComponentEntityToDTO,
+	)
+}
+
+// newMediaBatchFn creates a batch function for loading the media of products in their position order.
+func newMediaBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductMedia] {
+	batchFn := gqldataloader.NewGenericBatchFn(
+		repo.Media(),
+		[]string{"product_id"},
+		func(item *masterdataentity.ProductMedia) uuid.UUID {
+			return item.ProductId
+		},
+		nil,
+		productmapper.ProductMediaEntityToDTO,
+	)
+
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[[]*productdto.ProductMedia] {
+		results := batchFn(ctx, keys)
+		for _, result := range results {
+			sort.SliceStable(result.Data, func(i, j int) bool {
+				if result.Data[i].Position != result.Data[j].Position {
+					return result.Data[i].Position < result.Data[j].Position
+				}
+				return result.Data[i].CreatedAt.Before(result.Data[j].CreatedAt)
+			})
+		}
+		return results
+	}
+}
+
+// newTagBatchFn creates a batch function for loading the tags of products ordered by their slug.
+func newTagBatchFn(repo productrepo.Repository) dataloader.BatchFunc[uuid.UUID, []*productdto.ProductTag] {
+	return func(ctx context.Context, keys []uuid.UUID) []*dataloader.Result[[]*productdto.ProductTag] {
+		results := make([]*dataloader.Result[[]*productdto.ProductTag], len(keys))
+
+		links, err := repo.FindProductTagsIn(ctx, lo.Uniq(keys))
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result[[]*productdto.ProductTag]{Error: err}
+			}
+			return results
+		}
+
+		tags := make(map[uuid.UUID][]*productdto.ProductTag, len(keys))
+		for _, link := range links {
+			if link.Tag != nil {
+				tags[link.ProductId] = append(tags[link.ProductId], productmapper.ProductTagEntityToDTO(link.Tag))
+			}
+		}
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result[[]*productdto.ProductTag]{Data: tags[key]}
+		}
+
+		return results
+	}
+}
+
+// newRelatedProductsBatchFn creates a batch function for loading the published products related to products, most
+// related first. Keys are ranked in one query per distinct limit, weights and language.
+func newRelatedProductsBatchFn(repo productrepo.Repository) dataloader.BatchFunc[RelatedProductsKey, []*productdto.Product] {
+	return gqldataloader.NewGroupedBatchFn(
+		func(key RelatedProductsKey) relatedProductsGroup {
+			return key.relatedProductsGroup
+		},
+		func(ctx context.Context, group relatedProductsGroup, keys []RelatedProductsKey) (map[RelatedProductsKey][]*productdto.Product, error) {
+			productIds := lo.Map(keys, func(key RelatedProductsKey, _ int) uuid.UUID {
+				return key.ProductID
+			})
+
+			related, err := repo.FindRelatedProducts(ctx, productIds, productrepo.RelatedProductsOptions{
+				Limit:          group.Limit,
+				Status:         string(productdto.ProductStatusPublished),
+				CategoryWeight: group.CategoryWeight,
+				TagWeight:      group.TagWeight,
+			})
+			if err != nil || len(related) == 0 {
+				return nil, err
+			}
+
+			relatedIds := lo.Uniq(lo.Map(related, func(item *productrepo.RelatedProduct, _ int) uuid.UUID {
+				return item.RelatedProductId
+			}))
+
+			productEntities, err := repo.Product().FindIn(ctx, "id", lo.ToAnySlice(relatedIds), &crud.QueryOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			var translations map[uuid.UUID]*masterdataentity.ProductTranslation
+			if group.Language != language.Default {
+				translationEntities, err := repo.FindTranslationsIn(ctx, relatedIds, group.Language)
+				if err != nil {
+					return nil, err
+				}
+				translations = lo.KeyBy(translationEntities, func(translation *masterdataentity.ProductTranslation) uuid.UUID {
+					return translation.ProductId
+				})
+			}
+
+			products := make(map[uuid.UUID]*productdto.Product, len(productEntities))
+			for _, productEntity := range productEntities {
+				product := productmapper.ProductEntityToDTO(productEntity)
+				productmapper.ApplyProductTranslation(product, translations[product.ID])
+				products[product.ID] = product
+			}
+
+			result := make(map[RelatedProductsKey][]*productdto.Product, len(keys))
+			for _, item := range related {
+				key := RelatedProductsKey{ProductID: item.ProductId, relatedProductsGroup: group}
+				if product, ok := products[item.RelatedProductId]; ok {
+					result[key] = append(result[key], product)
+				}
+			}
+
+			return result, nil
+		},
 	)
 }
@@ -0,0 +1,16 @@
+package productdto
+
+import (
+	"github.com/google/uuid"
+)
+
+type CloneProductInput struct {
+	ID        uuid.UUID              `json:"id" validate:"required"`
+	Overrides *CloneProductOverrides `json:"overrides"`
+}
+
+// CloneProductOverrides is the content of the clone that differs from the cloned product.
+type CloneProductOverrides struct {
+	Name        *string `json:"name" validate:"omitempty,min=3"`
+	Description *string `json:"description"`
+}
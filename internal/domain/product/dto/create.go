@@ -4,14 +4,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/helper/money"
 )
 
 type CreateProductInput struct {
 	Name        string                      `json:"name" validate:"min=3"`
 	Description string                      `json:"description"`
-	Variants    []CreateProductVariantInput `json:"variants"`
+	Variants    []CreateProductVariantInput `json:"variants" validate:"dive"`
 }
 
 func (c *CreateProductInput) ToEntity(isNew bool) *masterdataentity.Product {
@@ -24,6 +26,7 @@ func (c *CreateProductInput) ToEntity(isNew bool) *masterdataentity.Product {
 	}
 
 	if isNew {
+		product.Status = string(ProductStatusDraft)
 		product.CreatedAt = time.Now()
 		product.Version = 1
 	} else {
@@ -49,17 +52,23 @@ func (c *CreateProductInput) ToEntity(isNew bool) *masterdataentity.Product {
 }
 
 type CreateProductVariantInput struct {
-	Sku             string                             `json:"sku"`
-	Price           float64                            `json:"price"`
-	DiscountedPrice float64                            `json:"discounted_price"`
-	Attributes      []CreateProductAttributeValueInput `json:"attributes"`
+	Sku   string      `json:"sku"`
+	Price money.Money `json:"price"`
+	// DiscountedPrice is in the currency of Price.
+	DiscountedPrice *decimal.Decimal `json:"discounted_price" validate:"omitempty,gte=0"`
+	// Prices is the price list of the variant in other currencies.
+	Prices     []ProductVariantPriceInput         `json:"prices" validate:"dive"`
+	Attributes []CreateProductAttributeValueInput `json:"attributes"`
 }
 
 func (c *CreateProductVariantInput) ToEntity(isNew bool) *masterdataentity.ProductVariant {
+	price := c.Price.Round()
+
 	productVariant := &masterdataentity.ProductVariant{
 		SKU:             c.Sku,
-		Price:           c.Price,
-		DiscountedPrice: c.DiscountedPrice,
+		Price:           price.Amount,
+		DiscountedPrice: roundDiscountedPrice(c.DiscountedPrice, price.Currency),
+		Currency:        price.Currency,
 	}
 
 	if isNew {
@@ -70,9 +79,44 @@ func (c *CreateProductVariantInput) ToEntity(isNew bool) *masterdataentity.Produ
 		productVariant.UpdatedAt = time.Now()
 	}
 
+	for _, p := range c.Prices {
+		productVariant.Prices = append(productVariant.Prices, p.ToEntity(productVariant.Id))
+	}
+
 	return productVariant
 }
 
+type ProductVariantPriceInput struct {
+	Price money.Money `json:"price"`
+	// DiscountedPrice is in the currency of Price.
+	DiscountedPrice *decimal.Decimal `json:"discounted_price" validate:"omitempty,gte=0"`
+}
+
+func (c *ProductVariantPriceInput) ToEntity(variantId uuid.UUID) *masterdataentity.ProductVariantPrice {
+	price := c.Price.Round()
+
+	return &masterdataentity.ProductVariantPrice{
+		Id:              uuid.New(),
+		VariantId:       variantId,
+		Currency:        price.Currency,
+		Price:           price.Amount,
+		DiscountedPrice: roundDiscountedPrice(c.DiscountedPrice, price.Currency),
+		CreatedAt:       time.Now(),
+	}
+}
+
+type SetProductVariantPricesInput struct {
+	VariantID uuid.UUID                  `json:"variantId" validate:"required"`
+	Prices    []ProductVariantPriceInput `json:"prices" validate:"dive"`
+}
+
+func roundDiscountedPrice(discountedPrice *decimal.Decimal, currency string) decimal.NullDecimal {
+	if discountedPrice == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NewNullDecimal(money.New(*discountedPrice, currency).Round().Amount)
+}
+
 type CreateProductAttributeValueInput struct {
 	ID    uuid.UUID `json:"id"`
 	Value string    `json:"value"`
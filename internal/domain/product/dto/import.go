@@ -0,0 +1,53 @@
+package productdto
+
+import (
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+// ImportProductField is the product field an imported column is mapped to.
+type ImportProductField string
+
+const (
+	ImportProductFieldName            ImportProductField = "NAME"
+	ImportProductFieldDescription     ImportProductField = "DESCRIPTION"
+	ImportProductFieldSku             ImportProductField = "SKU"
+	ImportProductFieldPrice           ImportProductField = "PRICE"
+	ImportProductFieldDiscountedPrice ImportProductField = "DISCOUNTED_PRICE"
+	ImportProductFieldCurrency        ImportProductField = "CURRENCY"
+	ImportProductFieldAttribute       ImportProductField = "ATTRIBUTE"
+)
+
+type ImportProductHeaderMappingInput struct {
+	Header      string             `json:"header"`
+	Field       ImportProductField `json:"field"`
+	AttributeID uuid.UUID          `json:"attributeId"`
+}
+
+type ImportProductsInput struct {
+	File          graphql.Upload                    `json:"file"`
+	Format        string                            `json:"format"`
+	SheetName     string                            `json:"sheetName"`
+	HeaderMapping []ImportProductHeaderMappingInput `json:"headerMapping"`
+	DryRun        bool                              `json:"dryRun"`
+	ChunkSize     int                               `json:"chunkSize" validate:"gte=0,lte=1000"`
+	// Currency is the currency of rows without a currency column.
+	Currency string `json:"currency" validate:"omitempty,iso4217"`
+}
+
+type ImportProductRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type ImportProductsResult struct {
+	DryRun              bool                     `json:"dryRun"`
+	TotalRows           int                      `json:"totalRows"`
+	ValidRows           int                      `json:"validRows"`
+	ImportedRows        int                      `json:"importedRows"`
+	RejectedRows        int                      `json:"rejectedRows"`
+	CreatedProducts     int                      `json:"createdProducts"`
+	Errors              []*ImportProductRowError `json:"errors"`
+	ErrorReport         *string                  `json:"errorReport"`
+	ErrorReportFilename *string                  `json:"errorReportFilename"`
+}
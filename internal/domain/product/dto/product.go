@@ -4,13 +4,60 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"gobase/internal/pkg/service/crud"
+)
+
+// ProductStatus is the publication state of a product. Only published products are listed publicly.
+type ProductStatus string
+
+const (
+	ProductStatusDraft     ProductStatus = "DRAFT"
+	ProductStatusInReview  ProductStatus = "IN_REVIEW"
+	ProductStatusPublished ProductStatus = "PUBLISHED"
+	ProductStatusArchived  ProductStatus = "ARCHIVED"
+)
+
+// ProductStatusTransition is a change of the publication state of a product.
+type ProductStatusTransition string
+
+const (
+	ProductStatusTransitionSubmitForReview ProductStatusTransition = "SUBMIT_FOR_REVIEW"
+	ProductStatusTransitionReject          ProductStatusTransition = "REJECT"
+	ProductStatusTransitionPublish         ProductStatusTransition = "PUBLISH"
+	ProductStatusTransitionUnpublish       ProductStatusTransition = "UNPUBLISH"
+	ProductStatusTransitionArchive         ProductStatusTransition = "ARCHIVE"
+	ProductStatusTransitionRestore         ProductStatusTransition = "RESTORE"
 )
 
 type Product struct {
-	ID          uuid.UUID         `json:"id"`
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
-	Variants    []*ProductVariant `json:"variants"`
+	ID          uuid.UUID     `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Status      ProductStatus `json:"status"`
+	// Language is the language of Name and Description.
+	Language string `json:"language"`
+	// Search and SearchLanguage are the full-text search the product was found with, if any.
+	Search         string            `json:"-"`
+	SearchLanguage string            `json:"-"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	Variants       []*ProductVariant `json:"variants"`
+}
+
+// ProductNodeType is the type name in the global ids of products.
+const ProductNodeType = "Product"
+
+// GlobalID returns the id identifying the product among the nodes of all types.
+func (p Product) GlobalID() string {
+	return crud.ToGlobalID(ProductNodeType, p.ID.String())
+}
+
+// IsEntity marks the product as a federation entity, resolved by other subgraphs by its id.
+func (Product) IsEntity() {}
+
+// ProductSearchHighlight is the name and description of a product with the words matching a search highlighted.
+type ProductSearchHighlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
 }
@@ -0,0 +1,113 @@
+package productdto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/utils/strings/slices"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/helper/money"
+	"gobase/internal/pkg/service/crud"
+)
+
+// ProductBundle is a set of variants sold together as the variant it is defined on.
+type ProductBundle struct {
+	ID        uuid.UUID   `json:"id"`
+	VariantID uuid.UUID   `json:"variant_id"`
+	Price     money.Money `json:"price"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type ProductBundleComponent struct {
+	ID        uuid.UUID `json:"id"`
+	BundleID  uuid.UUID `json:"bundle_id"`
+	VariantID uuid.UUID `json:"variant_id"`
+	Quantity  int       `json:"quantity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ProductBundleList = crud.PageResult[*ProductBundle]
+
+type ProductBundleComponentInput struct {
+	VariantID uuid.UUID `json:"variantId" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"gte=1"`
+}
+
+func (c *ProductBundleComponentInput) ToEntity(bundleId uuid.UUID) *masterdataentity.ProductBundleComponent {
+	return &masterdataentity.ProductBundleComponent{
+		Id:        uuid.New(),
+		BundleId:  bundleId,
+		VariantId: c.VariantID,
+		Quantity:  c.Quantity,
+		CreatedAt: time.Now(),
+	}
+}
+
+type CreateProductBundleInput struct {
+	// VariantID is the variant the bundle is sold as.
+	VariantID  uuid.UUID                     `json:"variantId" validate:"required"`
+	Price      money.Money                   `json:"price"`
+	Components []ProductBundleComponentInput `json:"components" validate:"dive"`
+}
+
+func (c *CreateProductBundleInput) ToEntity() *masterdataentity.ProductBundle {
+	price := c.Price.Round()
+	bundleId := uuid.New()
+
+	bundle := &masterdataentity.ProductBundle{
+		Id:        bundleId,
+		VariantId: c.VariantID,
+		Price:     price.Amount,
+		Currency:  price.Currency,
+		CreatedAt: time.Now(),
+	}
+
+	for _, component := range c.Components {
+		bundle.Components = append(bundle.Components, component.ToEntity(bundleId))
+	}
+
+	return bundle
+}
+
+type UpdateProductBundleInput struct {
+	ID    uuid.UUID    `json:"id" validate:"required"`
+	Price *money.Money `json:"price"`
+	// Components replaces the components of the bundle when given.
+	Components []ProductBundleComponentInput `json:"components" validate:"dive"`
+}
+
+// ProductBundleQopFilter defines the specific, allowed filters for bundles.
+type ProductBundleQopFilter struct {
+	VariantID    *uuid.UUID `filter:"field:product_variant_id;operator:eq"`
+	CreatedAtGte *time.Time `filter:"field:created_at;operator:gte"`
+	CreatedAtLte *time.Time `filter:"field:created_at;operator:lte"`
+}
+
+// ProductBundleQop (Query Options Provider) is an opinionated struct for bundle queries.
+type ProductBundleQop struct {
+	crud.QueryOptions
+	Filters ProductBundleQopFilter `json:"filters"`
+}
+
+// ToQueryOptions converts the opinionated ProductBundleQop to the generic crud.QueryOptions.
+func (q *ProductBundleQop) ToQueryOptions() *crud.QueryOptions {
+	qOpts := q.QueryOptions
+	qOpts.WithDefaultPagination()
+	qOpts.Filters = crud.BuildFilter(q.Filters)
+	return &qOpts
+}
+
+// WithAllowedSorts validates and sets the sorting options, ensuring only
+// whitelisted fields can be used for sorting.
+func (q *ProductBundleQop) WithAllowedSorts(allowedSorts []string) *ProductBundleQop {
+	var validatedSorts []crud.Sort
+	for _, s := range q.QueryOptions.Sorts {
+		if slices.Contains(allowedSorts, s.Field) {
+			validatedSorts = append(validatedSorts, s)
+		}
+	}
+	q.QueryOptions.Sorts = validatedSorts
+	return q
+}
@@ -0,0 +1,18 @@
+package productdto
+
+import "github.com/google/uuid"
+
+// ProductEventType is the kind of change a product event tells the GraphQL subscriptions about.
+type ProductEventType string
+
+const (
+	ProductEventCreated ProductEventType = "CREATED"
+	ProductEventUpdated ProductEventType = "UPDATED"
+)
+
+// ProductEvent is a change of a product broadcast to the GraphQL subscriptions of every instance. It only holds the id
+// of the product, which every subscription loads in the language of its own connection.
+type ProductEvent struct {
+	Type      ProductEventType `json:"type"`
+	ProductID uuid.UUID        `json:"productId"`
+}
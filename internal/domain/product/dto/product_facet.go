@@ -0,0 +1,16 @@
+package productdto
+
+import (
+	"github.com/google/uuid"
+)
+
+// ProductFacet is the number of products per value of an attribute.
+type ProductFacet struct {
+	AttributeID uuid.UUID            `json:"attributeId"`
+	Values      []*ProductFacetValue `json:"values"`
+}
+
+type ProductFacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,39 @@
+package productdto
+
+import (
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+type ProductMedia struct {
+	ID          uuid.UUID  `json:"id"`
+	ProductID   uuid.UUID  `json:"product_id"`
+	VariantID   *uuid.UUID `json:"variant_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	AltText     string     `json:"alt_text"`
+	Position    int        `json:"position"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	// StorageKey is where the file is kept in the blob storage. It is only exposed through signed URLs.
+	StorageKey string `json:"-"`
+}
+
+type UploadProductMediaInput struct {
+	ProductID uuid.UUID      `json:"productId" validate:"required"`
+	VariantID *uuid.UUID     `json:"variantId"`
+	File      graphql.Upload `json:"file"`
+	AltText   string         `json:"altText"`
+	// Position defaults to after the last media of the product.
+	Position *int `json:"position" validate:"omitempty,gte=0"`
+}
+
+type UpdateProductMediaInput struct {
+	ID        uuid.UUID  `json:"id" validate:"required"`
+	VariantID *uuid.UUID `json:"variantId"`
+	AltText   *string    `json:"altText"`
+	Position  *int       `json:"position" validate:"omitempty,gte=0"`
+}
@@ -3,6 +3,7 @@ package productdto
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"k8s.io/utils/strings/slices"
 
 	"gobase/internal/pkg/service/crud"
@@ -17,6 +18,23 @@ type ProductQopFilter struct {
 	UpdatedAt    *time.Time `filter:"field:updated_at;operator:eq"`
 	CreatedAtGte *time.Time `filter:"field:created_at;operator:gte"`
 	CreatedAtLte *time.Time `filter:"field:created_at;operator:lte"`
+	// Status defaults to published when not given.
+	Status *ProductStatus `filter:"field:status;operator:eq"`
+	// CategoryID limits the result to the products of a category, including its descendants.
+	// It is resolved by the use case since it needs a subquery on the category tree.
+	CategoryID *uuid.UUID
+	// AttributeValues limits the result to the products having a variant with one of the values of each attribute.
+	// It is resolved by the use case since it needs a subquery on the variant attribute values.
+	AttributeValues []*ProductAttributeValueFilter
+	// Tags limits the result to the products having any or all of the given tags.
+	// It is resolved by the use case since it needs a subquery on the product tags.
+	Tags *ProductTagFilter
+}
+
+// ProductAttributeValueFilter matches the products having a variant with one of the values of an attribute.
+type ProductAttributeValueFilter struct {
+	AttributeID uuid.UUID `json:"attributeId"`
+	Values      []string  `json:"values"`
 }
 
 // ProductQop (Query Options Provider) is an opinionated struct for product queries.
@@ -25,12 +43,16 @@ type ProductQopFilter struct {
 type ProductQop struct {
 	crud.QueryOptions
 	Filters ProductQopFilter `json:"filters"`
+	// Search is a full-text search on the name, description and variant SKUs. It is the search
+	// argument of the products query rather than a part of its qop input.
+	Search *string `json:"-"`
 }
 
 // ToQueryOptions converts the opinionated ProductQop to the generic crud.QueryOptions
 // that the repository layer can understand. It uses reflection to parse the `filter` tags.
 func (q *ProductQop) ToQueryOptions() *crud.QueryOptions {
 	qOpts := q.QueryOptions
+	qOpts.WithDefaultPagination()
 	qOpts.Filters = crud.BuildFilter(q.Filters)
 	return &qOpts
 }
@@ -0,0 +1,28 @@
+package productdto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductTag struct {
+	ID         uuid.UUID `json:"id"`
+	Slug       string    `json:"slug"`
+	Name       string    `json:"name"`
+	UsageCount int       `json:"usage_count"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ProductTagsInput adds or removes tags across many products. The tags are matched by their normalised slug.
+type ProductTagsInput struct {
+	ProductIDs []uuid.UUID `json:"productIds" validate:"required,min=1"`
+	Tags       []string    `json:"tags" validate:"required,min=1"`
+}
+
+// ProductTagFilter matches the products having any or all of the given tags. Both are AND-ed when given together.
+type ProductTagFilter struct {
+	AnyOf []string `json:"anyOf"`
+	AllOf []string `json:"allOf"`
+}
@@ -0,0 +1,41 @@
+package productdto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+)
+
+type ProductTranslation struct {
+	ID          uuid.UUID `json:"id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	Language    string    `json:"language"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ProductTranslationInput struct {
+	Language    string `json:"language" validate:"required,language"`
+	Name        string `json:"name" validate:"min=3"`
+	Description string `json:"description"`
+}
+
+func (c *ProductTranslationInput) ToEntity(productId uuid.UUID) *masterdataentity.ProductTranslation {
+	return &masterdataentity.ProductTranslation{
+		Id:          uuid.New(),
+		ProductId:   productId,
+		Language:    c.Language,
+		Name:        c.Name,
+		Description: c.Description,
+		CreatedAt:   time.Now(),
+	}
+}
+
+type SetProductTranslationsInput struct {
+	ProductID    uuid.UUID                 `json:"productId" validate:"required"`
+	Translations []ProductTranslationInput `json:"translations" validate:"dive"`
+}
@@ -4,15 +4,30 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"gobase/internal/pkg/helper/money"
 )
 
 type ProductVariant struct {
 	ID              uuid.UUID                `json:"id"`
 	ProductID       uuid.UUID                `json:"product_id"`
 	Sku             string                   `json:"sku"`
-	Price           float64                  `json:"price"`
-	DiscountedPrice float64                  `json:"discounted_price"`
+	Price           money.Money              `json:"price"`
+	DiscountedPrice *money.Money             `json:"discounted_price"`
 	CreatedAt       time.Time                `json:"created_at"`
 	UpdatedAt       time.Time                `json:"updated_at"`
 	Attributes      []*ProductAttributeValue `json:"attributes"`
 }
+
+// IsEntity marks the variant as a federation entity, resolved by other subgraphs by its id.
+func (ProductVariant) IsEntity() {}
+
+// ProductVariantPrice is the price of a variant in a currency other than its base currency.
+type ProductVariantPrice struct {
+	ID              uuid.UUID    `json:"id"`
+	VariantID       uuid.UUID    `json:"variant_id"`
+	Price           money.Money  `json:"price"`
+	DiscountedPrice *money.Money `json:"discounted_price"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,62 @@
+package productdto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/helper/money"
+)
+
+// ProductVariantPriceHistory is a price of a variant that is valid within a time window.
+type ProductVariantPriceHistory struct {
+	ID              uuid.UUID    `json:"id"`
+	VariantID       uuid.UUID    `json:"variant_id"`
+	Price           money.Money  `json:"price"`
+	DiscountedPrice *money.Money `json:"discounted_price"`
+	ValidFrom       time.Time    `json:"valid_from"`
+	ValidTo         *time.Time   `json:"valid_to"`
+	OpenedAt        *time.Time   `json:"opened_at"`
+	ClosedAt        *time.Time   `json:"closed_at"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+// ProductVariantEffectivePrice is the price of a variant at a point in time. It is the scheduled price
+// whose window contains that time, or the base price of the variant when there is none.
+type ProductVariantEffectivePrice struct {
+	Price           money.Money  `json:"price"`
+	DiscountedPrice *money.Money `json:"discounted_price"`
+	PriceHistoryID  *uuid.UUID   `json:"price_history_id"`
+	ValidFrom       *time.Time   `json:"valid_from"`
+	ValidTo         *time.Time   `json:"valid_to"`
+}
+
+type ScheduleProductVariantPriceInput struct {
+	VariantID uuid.UUID       `json:"variantId" validate:"required"`
+	Price     decimal.Decimal `json:"price" validate:"gte=0"`
+	// DiscountedPrice is in the currency of the variant.
+	DiscountedPrice *decimal.Decimal `json:"discountedPrice" validate:"omitempty,gte=0"`
+	ValidFrom       time.Time        `json:"validFrom" validate:"required"`
+	ValidTo         *time.Time       `json:"validTo" validate:"omitempty,gtfield=ValidFrom"`
+}
+
+// ToEntity builds the scheduled price in the currency of the variant.
+func (c *ScheduleProductVariantPriceInput) ToEntity(currency string) *masterdataentity.ProductVariantPriceHistory {
+	history := &masterdataentity.ProductVariantPriceHistory{
+		Id:              uuid.New(),
+		VariantId:       c.VariantID,
+		Currency:        currency,
+		Price:           money.New(c.Price, currency).Round().Amount,
+		DiscountedPrice: roundDiscountedPrice(c.DiscountedPrice, currency),
+		ValidFrom:       c.ValidFrom,
+		CreatedAt:       time.Now(),
+	}
+
+	if c.ValidTo != nil {
+		history.ValidTo = *c.ValidTo
+	}
+
+	return history
+}
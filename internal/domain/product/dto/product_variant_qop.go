@@ -0,0 +1,50 @@
+package productdto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"k8s.io/utils/strings/slices"
+
+	"gobase/internal/pkg/service/crud"
+)
+
+// ProductVariantQopFilter defines the specific, allowed filters for the variants of a product.
+// Tags are used to map these fields to the underlying database query.
+type ProductVariantQopFilter struct {
+	Sku          *string          `json:"sku,omitempty" filter:"field:sku;operator:ilike"`
+	Currency     *string          `json:"currency,omitempty" filter:"field:currency;operator:eq"`
+	PriceGte     *decimal.Decimal `json:"priceGte,omitempty" filter:"field:price;operator:gte"`
+	PriceLte     *decimal.Decimal `json:"priceLte,omitempty" filter:"field:price;operator:lte"`
+	CreatedAtGte *time.Time       `json:"createdAtGte,omitempty" filter:"field:created_at;operator:gte"`
+	CreatedAtLte *time.Time       `json:"createdAtLte,omitempty" filter:"field:created_at;operator:lte"`
+}
+
+// ProductVariantQop (Query Options Provider) is an opinionated struct for the variants of a product.
+// It is the arguments of the variants field of a product, encoded as a part of its dataloader key.
+type ProductVariantQop struct {
+	Sorts   []crud.Sort             `json:"sorts,omitempty"`
+	Filters ProductVariantQopFilter `json:"filters"`
+}
+
+// ToQueryOptions converts the opinionated ProductVariantQop to the generic crud.QueryOptions
+// that the repository layer can understand. It uses reflection to parse the `filter` tags.
+func (q *ProductVariantQop) ToQueryOptions() *crud.QueryOptions {
+	return &crud.QueryOptions{
+		Sorts:   q.Sorts,
+		Filters: crud.BuildFilter(q.Filters),
+	}
+}
+
+// WithAllowedSorts validates and sets the sorting options, ensuring only
+// whitelisted fields can be used for sorting.
+func (q *ProductVariantQop) WithAllowedSorts(allowedSorts []string) *ProductVariantQop {
+	var validatedSorts []crud.Sort
+	for _, s := range q.Sorts {
+		if slices.Contains(allowedSorts, s.Field) {
+			validatedSorts = append(validatedSorts, s)
+		}
+	}
+	q.Sorts = validatedSorts
+	return q
+}
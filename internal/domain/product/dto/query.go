@@ -5,3 +5,7 @@ import (
 )
 
 type ProductList = crud.PageResult[*Product]
+
+type ProductConnection = crud.Connection[*Product]
+
+type ProductEdge = crud.Edge[*Product]
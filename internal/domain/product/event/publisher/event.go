@@ -4,13 +4,54 @@ import (
 	"context"
 
 	wsql "github.com/ThreeDotsLabs/watermill-sql/v2/pkg/sql"
+	"github.com/google/uuid"
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
 	"gobase/internal/pkg/service/watermillsvc"
 )
 
+// VariantPriceWindowOpened and VariantPriceWindowClosed tell why the price of a variant changed.
+const (
+	VariantPriceWindowOpened = "window_opened"
+	VariantPriceWindowClosed = "window_closed"
+)
+
+// VariantPriceChanged is the message published when a scheduled price window of a variant opens or closes.
+type VariantPriceChanged struct {
+	VariantId    uuid.UUID                                    `json:"variantId"`
+	Reason       string                                       `json:"reason"`
+	PriceHistory *masterdataentity.ProductVariantPriceHistory `json:"priceHistory"`
+}
+
+// ProductStatusChanged is the message published when a product moves to another publication status.
+type ProductStatusChanged struct {
+	ProductId  uuid.UUID                 `json:"productId"`
+	Transition string                    `json:"transition"`
+	From       string                    `json:"from"`
+	To         string                    `json:"to"`
+	Product    *masterdataentity.Product `json:"product"`
+}
+
+// ProductTranslationsChanged, ProductPricesChanged, ProductTagsChanged and ProductMediaChanged tell what changed
+// in an updated product.
+const (
+	ProductTranslationsChanged = "translations"
+	ProductPricesChanged       = "prices"
+	ProductTagsChanged         = "tags"
+	ProductMediaChanged        = "media"
+)
+
+// ProductUpdated is the message published when the content of products changes outside of their status.
+type ProductUpdated struct {
+	ProductIds []uuid.UUID `json:"productIds"`
+	Change     string      `json:"change"`
+}
+
 type Event interface {
 	PublishProductCreated(ctx context.Context, tx wsql.ContextExecutor, product *masterdataentity.Product) error
+	PublishVariantPriceChanged(ctx context.Context, tx wsql.ContextExecutor, change *VariantPriceChanged) error
+	PublishProductStatusChanged(ctx context.Context, tx wsql.ContextExecutor, change *ProductStatusChanged) error
+	PublishProductUpdated(ctx context.Context, tx wsql.ContextExecutor, update *ProductUpdated) error
 }
 
 type EventModule struct {
@@ -30,7 +71,7 @@ func NewEvent(opts EventOpts) Event {
 }
 
 func (m *EventModule) PublishProductCreated(ctx context.Context, tx wsql.ContextExecutor, product *masterdataentity.Product) error {
-	msg, err := watermillsvc.BuildNewMessage(product)
+	msg, err := watermillsvc.BuildNewMessage(ctx, product)
 	if err != nil {
 		return err
 	}
@@ -40,3 +81,39 @@ func (m *EventModule) PublishProductCreated(ctx context.Context, tx wsql.Context
 	}
 	return publisher.Publish("product.created", msg)
 }
+
+func (m *EventModule) PublishVariantPriceChanged(ctx context.Context, tx wsql.ContextExecutor, change *VariantPriceChanged) error {
+	msg, err := watermillsvc.BuildNewMessage(ctx, change)
+	if err != nil {
+		return err
+	}
+	publisher, err := m.watermillsvc.WithTx(tx)
+	if err != nil {
+		return err
+	}
+	return publisher.Publish("variant.price_changed", msg)
+}
+
+func (m *EventModule) PublishProductStatusChanged(ctx context.Context, tx wsql.ContextExecutor, change *ProductStatusChanged) error {
+	msg, err := watermillsvc.BuildNewMessage(ctx, change)
+	if err != nil {
+		return err
+	}
+	publisher, err := m.watermillsvc.WithTx(tx)
+	if err != nil {
+		return err
+	}
+	return publisher.Publish("product.status_changed", msg)
+}
+
+func (m *EventModule) PublishProductUpdated(ctx context.Context, tx wsql.ContextExecutor, update *ProductUpdated) error {
+	msg, err := watermillsvc.BuildNewMessage(ctx, update)
+	if err != nil {
+		return err
+	}
+	publisher, err := m.watermillsvc.WithTx(tx)
+	if err != nil {
+		return err
+	}
+	return publisher.Publish("product.updated", msg)
+}
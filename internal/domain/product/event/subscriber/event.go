@@ -7,6 +7,8 @@ import (
 	"github.com/rs/zerolog/log"
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	producteventpublisher "gobase/internal/domain/product/event/publisher"
 	productusecase "gobase/internal/domain/product/usecase"
 	"gobase/internal/pkg/service/watermillsvc"
 )
@@ -14,6 +16,9 @@ import (
 type Event interface {
 	Subscribe()
 	HandleProductCreated(msg *message.Message) error
+	HandleProductStatusChanged(msg *message.Message) error
+	HandleVariantPriceChanged(msg *message.Message) error
+	HandleProductUpdated(msg *message.Message) error
 }
 
 type EventModule struct {
@@ -35,6 +40,9 @@ func NewEvent(opts EventOpts) Event {
 
 func (m *EventModule) Subscribe() {
 	m.watermillsvc.AddSubscription("product.created", m.HandleProductCreated)
+	m.watermillsvc.AddSubscription("product.status_changed", m.HandleProductStatusChanged)
+	m.watermillsvc.AddSubscription("variant.price_changed", m.HandleVariantPriceChanged)
+	m.watermillsvc.AddSubscription("product.updated", m.HandleProductUpdated)
 }
 
 func (m *EventModule) HandleProductCreated(msg *message.Message) error {
@@ -43,5 +51,34 @@ func (m *EventModule) HandleProductCreated(msg *message.Message) error {
 		return err
 	}
 	log.Info().Any("entity", entity).Msg("consuming message from topic product.created")
+	return m.productUseCase.BroadcastProductEvent(msg.Context(), productdto.ProductEventCreated, entity.Id)
+}
+
+func (m *EventModule) HandleProductStatusChanged(msg *message.Message) error {
+	change := &producteventpublisher.ProductStatusChanged{}
+	if err := json.Unmarshal(msg.Payload, change); err != nil {
+		return err
+	}
+	return m.productUseCase.BroadcastProductEvent(msg.Context(), productdto.ProductEventUpdated, change.ProductId)
+}
+
+func (m *EventModule) HandleVariantPriceChanged(msg *message.Message) error {
+	change := &producteventpublisher.VariantPriceChanged{}
+	if err := json.Unmarshal(msg.Payload, change); err != nil {
+		return err
+	}
+	return m.productUseCase.BroadcastVariantEvent(msg.Context(), change.VariantId)
+}
+
+func (m *EventModule) HandleProductUpdated(msg *message.Message) error {
+	update := &producteventpublisher.ProductUpdated{}
+	if err := json.Unmarshal(msg.Payload, update); err != nil {
+		return err
+	}
+	for _, productId := range update.ProductIds {
+		if err := m.productUseCase.BroadcastProductEvent(msg.Context(), productdto.ProductEventUpdated, productId); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,29 @@
+package producthandler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	productusecase "gobase/internal/domain/product/usecase"
+)
+
+// Handler is the interface for the product domain's REST endpoints.
+type Handler interface {
+	UploadMedia(fc *fiber.Ctx) error
+}
+
+// HandlerModule is the implementation of the Handler interface.
+type HandlerModule struct {
+	productUseCase productusecase.UseCase
+}
+
+// HandlerOptions holds the dependencies needed to create a new handler.
+type HandlerOptions struct {
+	ProductUseCase productusecase.UseCase
+}
+
+// NewHandler creates a new, configured product handler.
+func NewHandler(opts HandlerOptions) Handler {
+	return &HandlerModule{
+		productUseCase: opts.ProductUseCase,
+	}
+}
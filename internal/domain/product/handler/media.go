@@ -0,0 +1,67 @@
+package producthandler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper"
+)
+
+// UploadMedia adds a file to the media of a product from a multipart form with a file field and optional
+// variant_id, alt_text and position fields.
+func (h *HandlerModule) UploadMedia(fc *fiber.Ctx) error {
+	productId, err := uuid.Parse(fc.Params("id"))
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, "invalid product id")
+	}
+
+	fileHeader, err := fc.FormFile("file")
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, "file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	input := productdto.UploadProductMediaInput{
+		ProductID: productId,
+		File: graphql.Upload{
+			File:        file,
+			Filename:    fileHeader.Filename,
+			Size:        fileHeader.Size,
+			ContentType: fileHeader.Header.Get("Content-Type"),
+		},
+		AltText: fc.FormValue("alt_text"),
+	}
+
+	if value := fc.FormValue("variant_id"); value != "" {
+		variantId, err := uuid.Parse(value)
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, "invalid variant id")
+		}
+		input.VariantID = &variantId
+	}
+
+	if value := fc.FormValue("position"); value != "" {
+		position, err := strconv.Atoi(value)
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, "invalid position")
+		}
+		input.Position = &position
+	}
+
+	media, err := h.productUseCase.UploadMedia(fc.UserContext(), input)
+	if err != nil {
+		return err
+	}
+
+	return helper.NewOkResponse(fc.Status(http.StatusCreated), media)
+}
@@ -5,6 +5,7 @@ import (
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
 	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper/language"
 )
 
 func ProductEntityToDTO(productEntity *masterdataentity.Product) *productdto.Product {
@@ -12,6 +13,8 @@ func ProductEntityToDTO(productEntity *masterdataentity.Product) *productdto.Pro
 		ID:          productEntity.Id,
 		Name:        productEntity.Name,
 		Description: productEntity.Description,
+		Status:      productdto.ProductStatus(productEntity.Status),
+		Language:    language.Default,
 		CreatedAt:   productEntity.CreatedAt,
 		UpdatedAt:   productEntity.UpdatedAt,
 	}
@@ -0,0 +1,48 @@
+package productmapper
+
+import (
+	"github.com/google/uuid"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper/money"
+)
+
+func ProductBundleEntityToDTO(productBundleEntity *masterdataentity.ProductBundle) *productdto.ProductBundle {
+	return &productdto.ProductBundle{
+		ID:        productBundleEntity.Id,
+		VariantID: productBundleEntity.VariantId,
+		Price:     money.New(productBundleEntity.Price, productBundleEntity.Currency),
+		CreatedAt: productBundleEntity.CreatedAt,
+		UpdatedAt: productBundleEntity.UpdatedAt,
+	}
+}
+
+func ProductBundleComponentEntityToDTO(productBundleComponentEntity *masterdataentity.ProductBundleComponent) *productdto.ProductBundleComponent {
+	return &productdto.ProductBundleComponent{
+		ID:        productBundleComponentEntity.Id,
+		BundleID:  productBundleComponentEntity.BundleId,
+		VariantID: productBundleComponentEntity.VariantId,
+		Quantity:  productBundleComponentEntity.Quantity,
+		CreatedAt: productBundleComponentEntity.CreatedAt,
+	}
+}
+
+// ProductBundleAvailableStock returns how many bundles can be put together from the available stock of their
+// components, i.e. the lowest number of times a component's quantity fits in its stock. Components without an
+// available stock are not tracked and do not limit it. It returns nil when none of the components is tracked.
+func ProductBundleAvailableStock(components []*productdto.ProductBundleComponent, availableStocks map[uuid.UUID]*int) *int {
+	var available *int
+	for _, component := range components {
+		stock := availableStocks[component.VariantID]
+		if stock == nil || component.Quantity <= 0 {
+			continue
+		}
+		componentAvailable := max(*stock, 0) / component.Quantity
+		if available == nil || componentAvailable < *available {
+			available = &componentAvailable
+		}
+	}
+
+	return available
+}
@@ -0,0 +1,30 @@
+package productmapper
+
+import (
+	"github.com/google/uuid"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+)
+
+func ProductMediaEntityToDTO(productMediaEntity *masterdataentity.ProductMedia) *productdto.ProductMedia {
+	productMedia := &productdto.ProductMedia{
+		ID:          productMediaEntity.Id,
+		ProductID:   productMediaEntity.ProductId,
+		FileName:    productMediaEntity.FileName,
+		ContentType: productMediaEntity.ContentType,
+		Size:        productMediaEntity.Size,
+		AltText:     productMediaEntity.AltText,
+		Position:    productMediaEntity.Position,
+		CreatedAt:   productMediaEntity.CreatedAt,
+		UpdatedAt:   productMediaEntity.UpdatedAt,
+		StorageKey:  productMediaEntity.StorageKey,
+	}
+
+	if productMediaEntity.VariantId != uuid.Nil {
+		variantId := productMediaEntity.VariantId
+		productMedia.VariantID = &variantId
+	}
+
+	return productMedia
+}
@@ -0,0 +1,17 @@
+package productmapper
+
+import (
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+)
+
+func ProductTagEntityToDTO(productTagEntity *masterdataentity.ProductTag) *productdto.ProductTag {
+	return &productdto.ProductTag{
+		ID:         productTagEntity.Id,
+		Slug:       productTagEntity.Slug,
+		Name:       productTagEntity.Name,
+		UsageCount: productTagEntity.UsageCount,
+		CreatedAt:  productTagEntity.CreatedAt,
+		UpdatedAt:  productTagEntity.UpdatedAt,
+	}
+}
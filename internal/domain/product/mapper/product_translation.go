@@ -0,0 +1,32 @@
+package productmapper
+
+import (
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+)
+
+func ProductTranslationEntityToDTO(productTranslationEntity *masterdataentity.ProductTranslation) *productdto.ProductTranslation {
+	return &productdto.ProductTranslation{
+		ID:          productTranslationEntity.Id,
+		ProductID:   productTranslationEntity.ProductId,
+		Language:    productTranslationEntity.Language,
+		Name:        productTranslationEntity.Name,
+		Description: productTranslationEntity.Description,
+		CreatedAt:   productTranslationEntity.CreatedAt,
+		UpdatedAt:   productTranslationEntity.UpdatedAt,
+	}
+}
+
+// ApplyProductTranslation replaces the name and description of a product with their translation.
+// A missing translation or an empty description keeps the content in the default language.
+func ApplyProductTranslation(product *productdto.Product, productTranslationEntity *masterdataentity.ProductTranslation) {
+	if productTranslationEntity == nil {
+		return
+	}
+
+	product.Language = productTranslationEntity.Language
+	product.Name = productTranslationEntity.Name
+	if productTranslationEntity.Description != "" {
+		product.Description = productTranslationEntity.Description
+	}
+}
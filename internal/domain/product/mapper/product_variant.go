@@ -1,10 +1,14 @@
 package productmapper
 
 import (
+	"time"
+
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
 	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper/money"
 )
 
 func ProductVariantEntityToDTO(productVariantEntity *masterdataentity.ProductVariant) *productdto.ProductVariant {
@@ -12,8 +16,8 @@ func ProductVariantEntityToDTO(productVariantEntity *masterdataentity.ProductVar
 		ID:              productVariantEntity.Id,
 		ProductID:       productVariantEntity.ProductId,
 		Sku:             productVariantEntity.SKU,
-		Price:           productVariantEntity.Price,
-		DiscountedPrice: productVariantEntity.DiscountedPrice,
+		Price:           money.New(productVariantEntity.Price, productVariantEntity.Currency),
+		DiscountedPrice: nullDecimalToMoney(productVariantEntity.DiscountedPrice, productVariantEntity.Currency),
 		CreatedAt:       productVariantEntity.CreatedAt,
 		UpdatedAt:       productVariantEntity.UpdatedAt,
 	}
@@ -26,3 +30,62 @@ func ProductVariantEntityToDTO(productVariantEntity *masterdataentity.ProductVar
 
 	return productVariant
 }
+
+func ProductVariantPriceEntityToDTO(productVariantPriceEntity *masterdataentity.ProductVariantPrice) *productdto.ProductVariantPrice {
+	return &productdto.ProductVariantPrice{
+		ID:              productVariantPriceEntity.Id,
+		VariantID:       productVariantPriceEntity.VariantId,
+		Price:           money.New(productVariantPriceEntity.Price, productVariantPriceEntity.Currency),
+		DiscountedPrice: nullDecimalToMoney(productVariantPriceEntity.DiscountedPrice, productVariantPriceEntity.Currency),
+		CreatedAt:       productVariantPriceEntity.CreatedAt,
+		UpdatedAt:       productVariantPriceEntity.UpdatedAt,
+	}
+}
+
+func nullDecimalToMoney(amount decimal.NullDecimal, currency string) *money.Money {
+	if !amount.Valid {
+		return nil
+	}
+	m := money.New(amount.Decimal, currency)
+	return &m
+}
+
+func ProductVariantPriceHistoryEntityToDTO(productVariantPriceHistoryEntity *masterdataentity.ProductVariantPriceHistory) *productdto.ProductVariantPriceHistory {
+	return &productdto.ProductVariantPriceHistory{
+		ID:              productVariantPriceHistoryEntity.Id,
+		VariantID:       productVariantPriceHistoryEntity.VariantId,
+		Price:           money.New(productVariantPriceHistoryEntity.Price, productVariantPriceHistoryEntity.Currency),
+		DiscountedPrice: nullDecimalToMoney(productVariantPriceHistoryEntity.DiscountedPrice, productVariantPriceHistoryEntity.Currency),
+		ValidFrom:       productVariantPriceHistoryEntity.ValidFrom,
+		ValidTo:         zeroTimeToNil(productVariantPriceHistoryEntity.ValidTo),
+		OpenedAt:        zeroTimeToNil(productVariantPriceHistoryEntity.OpenedAt),
+		ClosedAt:        zeroTimeToNil(productVariantPriceHistoryEntity.ClosedAt),
+		CreatedAt:       productVariantPriceHistoryEntity.CreatedAt,
+	}
+}
+
+// ProductVariantEffectivePrice returns the price of a variant within the given scheduled price window,
+// falling back to the base price of the variant when the window is nil.
+func ProductVariantEffectivePrice(productVariant *productdto.ProductVariant, productVariantPriceHistory *productdto.ProductVariantPriceHistory) *productdto.ProductVariantEffectivePrice {
+	if productVariantPriceHistory == nil {
+		return &productdto.ProductVariantEffectivePrice{
+			Price:           productVariant.Price,
+			DiscountedPrice: productVariant.DiscountedPrice,
+		}
+	}
+
+	return &productdto.ProductVariantEffectivePrice{
+		Price:           productVariantPriceHistory.Price,
+		DiscountedPrice: productVariantPriceHistory.DiscountedPrice,
+		PriceHistoryID:  &productVariantPriceHistory.ID,
+		ValidFrom:       &productVariantPriceHistory.ValidFrom,
+		ValidTo:         productVariantPriceHistory.ValidTo,
+	}
+}
+
+func zeroTimeToNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
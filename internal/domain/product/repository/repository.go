@@ -2,11 +2,20 @@ package productrepository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
+	"gobase/internal/pkg/helper/language"
 	"gobase/internal/pkg/service/buncrud"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
 )
 
 // Repository defines the data access layer for the entire Product aggregate.
@@ -25,15 +34,185 @@ type Repository interface {
 
 	// VariantAttributeValue returns a repository for the variant-attribute relationship.
 	VariantAttributeValue() buncrud.BaseRepository[masterdataentity.RelProductVariantProductAttribute]
+
+	// VariantPrice returns a repository for the per-currency price list of the variants.
+	VariantPrice() buncrud.BaseRepository[masterdataentity.ProductVariantPrice]
+
+	// ReplaceVariantPrices replaces the price list of a variant with the given prices.
+	ReplaceVariantPrices(ctx context.Context, variantId uuid.UUID, prices []*masterdataentity.ProductVariantPrice) error
+
+	// TransitionStatus moves a product from one of the given statuses to another. It returns crud.ErrNotFound
+	// when the product does not exist or is not in one of the given statuses anymore.
+	TransitionStatus(ctx context.Context, id uuid.UUID, from []string, to string) (*masterdataentity.Product, error)
+
+	// Translation returns a repository for the translated content of the products.
+	Translation() buncrud.BaseRepository[masterdataentity.ProductTranslation]
+
+	// ReplaceTranslations replaces the translations of a product with the given translations.
+	ReplaceTranslations(ctx context.Context, productId uuid.UUID, translations []*masterdataentity.ProductTranslation) error
+
+	// FindTranslationsIn returns the translations of the given products in a language.
+	FindTranslationsIn(ctx context.Context, productIds []uuid.UUID, lang string) ([]*masterdataentity.ProductTranslation, error)
+
+	// ProductIdsByTranslatedNameQuery returns a subquery selecting the ids of the products whose name in the
+	// given language contains the given text. Products without a translation in that
+	// language are matched on their name in the default language.
+	ProductIdsByTranslatedNameQuery(lang string, name string) *bun.SelectQuery
+
+	// RefreshSearchDocuments rebuilds the full-text search documents of the given products in every supported language.
+	RefreshSearchDocuments(ctx context.Context, productIds []uuid.UUID) error
+
+	// Search returns the products matching a full-text search in the given language, most relevant first.
+	// The sorts of the options take precedence over the relevance.
+	Search(ctx context.Context, options *crud.QueryOptions, search string, lang string) (*crud.PageResult[masterdataentity.Product], error)
+
+	// FindSearchHighlightsIn returns the name and description of the given products in a language,
+	// with the words matching a full-text search highlighted.
+	FindSearchHighlightsIn(ctx context.Context, productIds []uuid.UUID, search string, lang string) ([]*SearchHighlight, error)
+
+	// VariantPriceHistory returns a repository for the scheduled prices of the variants.
+	VariantPriceHistory() buncrud.BaseRepository[masterdataentity.ProductVariantPriceHistory]
+
+	// FindEffectivePricesIn returns, for each of the given variants, the scheduled price whose window contains at.
+	// When windows overlap the one that started last wins. Variants without such a window are left out.
+	FindEffectivePricesIn(ctx context.Context, variantIds []uuid.UUID, at time.Time) ([]*masterdataentity.ProductVariantPriceHistory, error)
+
+	// OpenDuePriceWindows marks up to limit windows that started at or before now as opened and returns them.
+	OpenDuePriceWindows(ctx context.Context, now time.Time, limit int) ([]*masterdataentity.ProductVariantPriceHistory, error)
+
+	// CloseDuePriceWindows marks up to limit opened windows that ended at or before now as closed and returns them.
+	CloseDuePriceWindows(ctx context.Context, now time.Time, limit int) ([]*masterdataentity.ProductVariantPriceHistory, error)
+
+	// ProductIdsWithAttributeValuesQuery returns a subquery selecting the ids of the products having a variant
+	// whose value of the given attribute is one of the given values.
+	ProductIdsWithAttributeValuesQuery(attributeId uuid.UUID, values []string) *bun.SelectQuery
+
+	// FindAttributeFacets counts, per attribute value, the products matching the filters of the options.
+	// Only the given attributes are counted, or every attribute but them when exclude is set.
+	FindAttributeFacets(ctx context.Context, options *crud.QueryOptions, attributeIds []uuid.UUID, exclude bool) ([]*AttributeFacet, error)
+
+	// FindAggregateByID returns a product with its translations and its variants with their attribute values and prices.
+	// It returns crud.ErrNotFound when the product does not exist.
+	FindAggregateByID(ctx context.Context, id uuid.UUID) (*masterdataentity.Product, error)
+
+	// FindVariantSkusWithPrefixes returns the SKUs of the variants starting with one of the given prefixes.
+	FindVariantSkusWithPrefixes(ctx context.Context, prefixes []string) ([]string, error)
+
+	// Bundle returns a repository for the bundles sold as a variant.
+	Bundle() buncrud.BaseRepository[masterdataentity.ProductBundle]
+
+	// BundleComponent returns a repository for the variants making up the bundles.
+	BundleComponent() buncrud.BaseRepository[masterdataentity.ProductBundleComponent]
+
+	// ReplaceBundleComponents replaces the components of a bundle with the given components.
+	ReplaceBundleComponents(ctx context.Context, bundleId uuid.UUID, components []*masterdataentity.ProductBundleComponent) error
+
+	// DeleteBundle deletes a bundle with its components. It returns crud.ErrNotFound when the bundle does not exist.
+	DeleteBundle(ctx context.Context, id uuid.UUID) error
+
+	// LockVariants locks the rows of the variants until the end of the transaction, so that the bundles they are part
+	// of can be checked and changed without racing other transactions. It must be called inside a transaction.
+	LockVariants(ctx context.Context, ids []uuid.UUID) error
+
+	// Media returns a repository for the images and videos of the products.
+	Media() buncrud.BaseRepository[masterdataentity.ProductMedia]
+
+	// NextMediaPosition returns the position after the last media of a product.
+	NextMediaPosition(ctx context.Context, productId uuid.UUID) (int, error)
+
+	// FindOrphanMedia returns up to limit media whose product has been deleted.
+	FindOrphanMedia(ctx context.Context, limit int) ([]*masterdataentity.ProductMedia, error)
+
+	// DeleteMediaIn deletes the given media.
+	DeleteMediaIn(ctx context.Context, ids []uuid.UUID) error
+
+	// Tag returns a repository for the free-form tags of the products.
+	Tag() buncrud.BaseRepository[masterdataentity.ProductTag]
+
+	// FindOrCreateTags returns the tags with the slugs of the given tags, creating the ones that do not exist yet.
+	FindOrCreateTags(ctx context.Context, tags []*masterdataentity.ProductTag) ([]*masterdataentity.ProductTag, error)
+
+	// AddProductTags links every given product to every given tag. Existing links are kept.
+	AddProductTags(ctx context.Context, productIds []uuid.UUID, tagIds []uuid.UUID) error
+
+	// RemoveProductTags unlinks every given product from every given tag.
+	RemoveProductTags(ctx context.Context, productIds []uuid.UUID, tagIds []uuid.UUID) error
+
+	// RefreshTagUsageCounts recounts the products having each of the given tags.
+	RefreshTagUsageCounts(ctx context.Context, tagIds []uuid.UUID) error
+
+	// FindTagsByPrefix returns up to limit tags whose slug starts with the given prefix, most used first.
+	FindTagsByPrefix(ctx context.Context, prefix string, limit int) ([]*masterdataentity.ProductTag, error)
+
+	// FindProductTagsIn returns the links of the given products to their tags, with the tags loaded.
+	FindProductTagsIn(ctx context.Context, productIds []uuid.UUID) ([]*masterdataentity.RelProductTag, error)
+
+	// ProductIdsWithTagQuery returns a subquery selecting the ids of the products having the tag with the given slug.
+	ProductIdsWithTagQuery(slug string) *bun.SelectQuery
+
+	// FindRelatedProducts returns, for each of the given products, up to options.Limit other products of the given
+	// status ranked by the number of attribute values they share, plus the weighted number of shared categories and tags.
+	FindRelatedProducts(ctx context.Context, productIds []uuid.UUID, options RelatedProductsOptions) ([]*RelatedProduct, error)
+}
+
+// SearchHighlight is the content of a product with the words matching a full-text search highlighted.
+type SearchHighlight struct {
+	ProductId   uuid.UUID `bun:"product_id"`
+	Name        string    `bun:"name"`
+	Description string    `bun:"description"`
+}
+
+// AttributeFacet is the number of products having a value of an attribute.
+type AttributeFacet struct {
+	AttributeId uuid.UUID `bun:"product_attribute_id"`
+	Value       string    `bun:"value"`
+	Count       int64     `bun:"count"`
+}
+
+// RelatedProduct is a product related to another one, with the score it was ranked by.
+type RelatedProduct struct {
+	ProductId        uuid.UUID `bun:"product_id"`
+	RelatedProductId uuid.UUID `bun:"related_product_id"`
+	Score            float64   `bun:"score"`
+}
+
+// RelatedProductsOptions tunes the ranking of related products. Each shared attribute value scores 1, while shared
+// categories and tags score their weight, so a zero weight leaves them out.
+type RelatedProductsOptions struct {
+	Limit          int
+	Status         string
+	CategoryWeight float64
+	TagWeight      float64
+}
+
+// searchConfigs maps the supported languages to their Postgres text search configuration.
+var searchConfigs = map[string]string{
+	"en": "english",
+	"id": "indonesian",
+}
+
+// searchConfig returns the text search configuration of a language, or the language agnostic one.
+func searchConfig(lang string) string {
+	if config, ok := searchConfigs[lang]; ok {
+		return config
+	}
+	return "simple"
 }
 
 // RepositoryModule is the implementation of the Repository interface.
 type RepositoryModule struct {
-	productsRepo        buncrud.BaseRepository[masterdataentity.Product]
-	variantsRepo        buncrud.BaseRepository[masterdataentity.ProductVariant]
-	attributesRepo      buncrud.BaseRepository[masterdataentity.ProductAttribute]
-	attributeValuesRepo buncrud.BaseRepository[masterdataentity.RelProductVariantProductAttribute]
-	db                  bun.IDB // Can be *bun.DB or *bun.Tx
+	productsRepo         buncrud.BaseRepository[masterdataentity.Product]
+	variantsRepo         buncrud.BaseRepository[masterdataentity.ProductVariant]
+	attributesRepo       buncrud.BaseRepository[masterdataentity.ProductAttribute]
+	attributeValuesRepo  buncrud.BaseRepository[masterdataentity.RelProductVariantProductAttribute]
+	variantPricesRepo    buncrud.BaseRepository[masterdataentity.ProductVariantPrice]
+	priceHistoriesRepo   buncrud.BaseRepository[masterdataentity.ProductVariantPriceHistory]
+	translationsRepo     buncrud.BaseRepository[masterdataentity.ProductTranslation]
+	bundlesRepo          buncrud.BaseRepository[masterdataentity.ProductBundle]
+	bundleComponentsRepo buncrud.BaseRepository[masterdataentity.ProductBundleComponent]
+	mediaRepo            buncrud.BaseRepository[masterdataentity.ProductMedia]
+	tagsRepo             buncrud.BaseRepository[masterdataentity.ProductTag]
+	db                   bun.IDB // Can be *bun.DB or *bun.Tx
 }
 
 type RepositoryOpts struct {
@@ -43,22 +222,36 @@ type RepositoryOpts struct {
 // NewRepository creates a new repository for the Product aggregate.
 func NewRepository(opts RepositoryOpts) Repository {
 	return &RepositoryModule{
-		productsRepo:        buncrud.NewBaseRepository[masterdataentity.Product](opts.Bun),
-		variantsRepo:        buncrud.NewBaseRepository[masterdataentity.ProductVariant](opts.Bun),
-		attributesRepo:      buncrud.NewBaseRepository[masterdataentity.ProductAttribute](opts.Bun),
-		attributeValuesRepo: buncrud.NewBaseRepository[masterdataentity.RelProductVariantProductAttribute](opts.Bun),
-		db:                  opts.Bun,
+		productsRepo:         buncrud.NewBaseRepository[masterdataentity.Product](opts.Bun),
+		variantsRepo:         buncrud.NewBaseRepository[masterdataentity.ProductVariant](opts.Bun),
+		attributesRepo:       buncrud.NewBaseRepository[masterdataentity.ProductAttribute](opts.Bun),
+		attributeValuesRepo:  buncrud.NewBaseRepository[masterdataentity.RelProductVariantProductAttribute](opts.Bun),
+		variantPricesRepo:    buncrud.NewBaseRepository[masterdataentity.ProductVariantPrice](opts.Bun),
+		priceHistoriesRepo:   buncrud.NewBaseRepository[masterdataentity.ProductVariantPriceHistory](opts.Bun),
+		translationsRepo:     buncrud.NewBaseRepository[masterdataentity.ProductTranslation](opts.Bun),
+		bundlesRepo:          buncrud.NewBaseRepository[masterdataentity.ProductBundle](opts.Bun),
+		bundleComponentsRepo: buncrud.NewBaseRepository[masterdataentity.ProductBundleComponent](opts.Bun),
+		mediaRepo:            buncrud.NewBaseRepository[masterdataentity.ProductMedia](opts.Bun),
+		tagsRepo:             buncrud.NewBaseRepository[masterdataentity.ProductTag](opts.Bun),
+		db:                   opts.Bun,
 	}
 }
 
 // WithTx returns a new repository instance that uses the provided transaction.
 func (r *RepositoryModule) WithTx(ctx context.Context, tx bun.Tx) Repository {
 	return &RepositoryModule{
-		productsRepo:        r.productsRepo.WithTx(ctx, tx),
-		variantsRepo:        r.variantsRepo.WithTx(ctx, tx),
-		attributesRepo:      r.attributesRepo.WithTx(ctx, tx),
-		attributeValuesRepo: r.attributeValuesRepo.WithTx(ctx, tx),
-		db:                  tx,
+		productsRepo:         r.productsRepo.WithTx(ctx, tx),
+		variantsRepo:         r.variantsRepo.WithTx(ctx, tx),
+		attributesRepo:       r.attributesRepo.WithTx(ctx, tx),
+		attributeValuesRepo:  r.attributeValuesRepo.WithTx(ctx, tx),
+		variantPricesRepo:    r.variantPricesRepo.WithTx(ctx, tx),
+		priceHistoriesRepo:   r.priceHistoriesRepo.WithTx(ctx, tx),
+		translationsRepo:     r.translationsRepo.WithTx(ctx, tx),
+		bundlesRepo:          r.bundlesRepo.WithTx(ctx, tx),
+		bundleComponentsRepo: r.bundleComponentsRepo.WithTx(ctx, tx),
+		mediaRepo:            r.mediaRepo.WithTx(ctx, tx),
+		tagsRepo:             r.tagsRepo.WithTx(ctx, tx),
+		db:                   tx,
 	}
 }
 
@@ -77,3 +270,805 @@ func (r *RepositoryModule) Attribute() buncrud.BaseRepository[masterdataentity.P
 func (r *RepositoryModule) VariantAttributeValue() buncrud.BaseRepository[masterdataentity.RelProductVariantProductAttribute] {
 	return r.attributeValuesRepo
 }
+
+func (r *RepositoryModule) VariantPrice() buncrud.BaseRepository[masterdataentity.ProductVariantPrice] {
+	return r.variantPricesRepo
+}
+
+func (r *RepositoryModule) ReplaceVariantPrices(ctx context.Context, variantId uuid.UUID, prices []*masterdataentity.ProductVariantPrice) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/ReplaceVariantPrices", map[string]any{
+		"variantId": variantId,
+	})
+	defer span.End()
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.ProductVariantPrice)(nil)).
+		Where("product_variant_id = ?", variantId).
+		Exec(ctx)
+	if err != nil || len(prices) == 0 {
+		return err
+	}
+
+	_, err = r.variantPricesRepo.CreateBulk(ctx, prices)
+	return err
+}
+
+func (r *RepositoryModule) TransitionStatus(ctx context.Context, id uuid.UUID, from []string, to string) (*masterdataentity.Product, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/TransitionStatus", map[string]any{
+		"id":   id,
+		"from": from,
+		"to":   to,
+	})
+	defer span.End()
+
+	product := &masterdataentity.Product{}
+
+	// The table is updated directly so the version hooks of the model do not apply. The status
+	// condition already guards against concurrent transitions, and the version is bumped here.
+	_, err := r.db.NewUpdate().
+		Table("product").
+		Set("status = ?", to).
+		Set("version = version + 1").
+		Set("updated_at = NOW()").
+		Where("id = ?", id).
+		Where("status IN (?)", bun.In(from)).
+		Where("?", buncrud.NotDeleted[masterdataentity.Product](r.db, "product")).
+		Returning("*").
+		Exec(ctx, product)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, crud.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if product.Id == uuid.Nil {
+		return nil, crud.ErrNotFound
+	}
+
+	return product, nil
+}
+
+func (r *RepositoryModule) Translation() buncrud.BaseRepository[masterdataentity.ProductTranslation] {
+	return r.translationsRepo
+}
+
+func (r *RepositoryModule) ReplaceTranslations(ctx context.Context, productId uuid.UUID, translations []*masterdataentity.ProductTranslation) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/ReplaceTranslations", map[string]any{
+		"productId": productId,
+	})
+	defer span.End()
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.ProductTranslation)(nil)).
+		Where("product_id = ?", productId).
+		Exec(ctx)
+	if err != nil || len(translations) == 0 {
+		return err
+	}
+
+	_, err = r.translationsRepo.CreateBulk(ctx, translations)
+	return err
+}
+
+func (r *RepositoryModule) FindTranslationsIn(ctx context.Context, productIds []uuid.UUID, lang string) ([]*masterdataentity.ProductTranslation, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindTranslationsIn", map[string]any{
+		"productIds": productIds,
+		"language":   lang,
+	})
+	defer span.End()
+
+	var translations []*masterdataentity.ProductTranslation
+	if len(productIds) == 0 {
+		return translations, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&translations).
+		Where("product_id IN (?)", bun.In(productIds)).
+		Where("language = ?", lang).
+		Scan(ctx)
+
+	return translations, err
+}
+
+func (r *RepositoryModule) ProductIdsByTranslatedNameQuery(lang string, name string) *bun.SelectQuery {
+	return r.db.NewSelect().
+		TableExpr("product AS p").
+		Column("p.id").
+		Join("LEFT JOIN product_translation AS pt ON pt.product_id = p.id AND pt.language = ?", lang).
+		Where("COALESCE(pt.name, p.name) LIKE ?", "%"+name+"%")
+}
+
+func (r *RepositoryModule) RefreshSearchDocuments(ctx context.Context, productIds []uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/RefreshSearchDocuments", map[string]any{
+		"productIds": productIds,
+	})
+	defer span.End()
+
+	if len(productIds) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.ProductSearch)(nil)).
+		Where("product_id IN (?)", bun.In(productIds)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	languages := make([]string, 0, len(language.Supported))
+	configs := make([]string, 0, len(language.Supported))
+	for _, lang := range language.Supported {
+		languages = append(languages, lang)
+		configs = append(configs, searchConfig(lang))
+	}
+
+	// The name weighs the most, then the SKUs, then the description. SKUs are codes rather than words,
+	// so they are not stemmed.
+	_, err = r.db.NewRaw(
+		`INSERT INTO product_search (product_id, language, document)
+		SELECT p.id, l.language,
+			setweight(to_tsvector(l.config::regconfig, COALESCE(pt.name, p.name)), 'A') ||
+			setweight(to_tsvector('simple', COALESCE((
+				SELECT string_agg(v.sku, ' ') FROM product_variant AS v
+				WHERE v.product_id = p.id AND ?
+			), '')), 'B') ||
+			setweight(to_tsvector(l.config::regconfig, COALESCE(NULLIF(pt.description, ''), p.description, '')), 'C')
+		FROM product AS p
+		CROSS JOIN unnest(?::text[], ?::text[]) AS l(language, config)
+		LEFT JOIN product_translation AS pt ON pt.product_id = p.id AND pt.language = l.language
+		WHERE p.id IN (?) AND ?`,
+		buncrud.NotDeleted[masterdataentity.ProductVariant](r.db, "v"),
+		pgdialect.Array(languages), pgdialect.Array(configs), bun.In(productIds),
+		buncrud.NotDeleted[masterdataentity.Product](r.db, "p"),
+	).Exec(ctx)
+
+	return err
+}
+
+func (r *RepositoryModule) Search(ctx context.Context, options *crud.QueryOptions, search string, lang string) (*crud.PageResult[masterdataentity.Product], error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/Search", map[string]any{
+		"search":   search,
+		"language": lang,
+	})
+	defer span.End()
+
+	if options == nil {
+		options = crud.NewQueryOptions()
+	}
+
+	query := r.productsRepo.QueryBuilder(ctx, options).
+		Join("JOIN product_search AS ps ON ps.product_id = product.id AND ps.language = ?", lang).
+		Join("CROSS JOIN (?) AS sq", searchQuery(r.db, search, lang)).
+		Where("ps.document @@ sq.query").
+		OrderExpr("ts_rank(ps.document, sq.query) DESC").
+		OrderExpr("product.id ASC")
+
+	var count int
+	var err error
+	if options.Pagination != nil && options.Pagination.WithCount {
+		count, err = query.Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var products []masterdataentity.Product
+	if err := query.Scan(ctx, &products); err != nil {
+		return nil, err
+	}
+
+	pageResult := &crud.PageResult[masterdataentity.Product]{
+		Items: products,
+	}
+
+	if options.Pagination != nil {
+		pageResult.Pagination.Page = options.Pagination.Page
+		pageResult.Pagination.PageSize = options.Pagination.PageSize
+
+		if options.Pagination.WithCount {
+			pageResult.Pagination.TotalRows = int64(count)
+			if options.Pagination.PageSize > 0 {
+				pageResult.Pagination.TotalPages = int(math.Ceil(float64(count) / float64(options.Pagination.PageSize)))
+			}
+			pageResult.Pagination.HasNext = pageResult.Pagination.Page*pageResult.Pagination.PageSize < int(pageResult.Pagination.TotalRows)
+		}
+	}
+
+	return pageResult, nil
+}
+
+func (r *RepositoryModule) FindSearchHighlightsIn(ctx context.Context, productIds []uuid.UUID, search string, lang string) ([]*SearchHighlight, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindSearchHighlightsIn", map[string]any{
+		"productIds": productIds,
+		"search":     search,
+		"language":   lang,
+	})
+	defer span.End()
+
+	var highlights []*SearchHighlight
+	if len(productIds) == 0 {
+		return highlights, nil
+	}
+
+	config := searchConfig(lang)
+
+	err := r.db.NewSelect().
+		TableExpr("product AS p").
+		ColumnExpr("p.id AS product_id").
+		ColumnExpr("ts_headline(?::regconfig, COALESCE(pt.name, p.name), sq.query, 'HighlightAll=true') AS name", config).
+		ColumnExpr("ts_headline(?::regconfig, COALESCE(NULLIF(pt.description, ''), p.description, ''), sq.query, 'MaxFragments=2') AS description", config).
+		Join("LEFT JOIN product_translation AS pt ON pt.product_id = p.id AND pt.language = ?", lang).
+		Join("CROSS JOIN (?) AS sq", searchQuery(r.db, search, lang)).
+		Where("p.id IN (?)", bun.In(productIds)).
+		Scan(ctx, &highlights)
+
+	return highlights, err
+}
+
+// searchQuery returns a subquery selecting the tsquery of a search as the query column. The search is parsed
+// with the configuration of the language, and as is to also match the SKUs.
+func searchQuery(db bun.IDB, search string, lang string) *bun.SelectQuery {
+	return db.NewSelect().
+		ColumnExpr("websearch_to_tsquery(?::regconfig, ?) || websearch_to_tsquery('simple', ?) AS query", searchConfig(lang), search, search)
+}
+
+func (r *RepositoryModule) VariantPriceHistory() buncrud.BaseRepository[masterdataentity.ProductVariantPriceHistory] {
+	return r.priceHistoriesRepo
+}
+
+func (r *RepositoryModule) FindEffectivePricesIn(ctx context.Context, variantIds []uuid.UUID, at time.Time) ([]*masterdataentity.ProductVariantPriceHistory, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindEffectivePricesIn", map[string]any{
+		"variantIds": variantIds,
+		"at":         at,
+	})
+	defer span.End()
+
+	var histories []*masterdataentity.ProductVariantPriceHistory
+	if len(variantIds) == 0 {
+		return histories, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&histories).
+		DistinctOn("product_variant_id").
+		Where("product_variant_id IN (?)", bun.In(variantIds)).
+		Where("valid_from <= ?", at).
+		Where("(valid_to IS NULL OR valid_to > ?)", at).
+		OrderExpr("product_variant_id, valid_from DESC").
+		Scan(ctx)
+
+	return histories, err
+}
+
+func (r *RepositoryModule) OpenDuePriceWindows(ctx context.Context, now time.Time, limit int) ([]*masterdataentity.ProductVariantPriceHistory, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/OpenDuePriceWindows", map[string]any{
+		"now":   now,
+		"limit": limit,
+	})
+	defer span.End()
+
+	due := r.db.NewSelect().
+		Model((*masterdataentity.ProductVariantPriceHistory)(nil)).
+		Column("id").
+		Where("opened_at IS NULL").
+		Where("valid_from <= ?", now).
+		OrderExpr("valid_from ASC").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	return r.markPriceWindows(ctx, "opened_at", now, due)
+}
+
+func (r *RepositoryModule) CloseDuePriceWindows(ctx context.Context, now time.Time, limit int) ([]*masterdataentity.ProductVariantPriceHistory, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/CloseDuePriceWindows", map[string]any{
+		"now":   now,
+		"limit": limit,
+	})
+	defer span.End()
+
+	due := r.db.NewSelect().
+		Model((*masterdataentity.ProductVariantPriceHistory)(nil)).
+		Column("id").
+		Where("opened_at IS NOT NULL").
+		Where("closed_at IS NULL").
+		Where("valid_to <= ?", now).
+		OrderExpr("valid_to ASC").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	return r.markPriceWindows(ctx, "closed_at", now, due)
+}
+
+// markPriceWindows sets the given timestamp column of the windows selected by the subquery.
+func (r *RepositoryModule) markPriceWindows(ctx context.Context, column string, now time.Time, due *bun.SelectQuery) ([]*masterdataentity.ProductVariantPriceHistory, error) {
+	var histories []*masterdataentity.ProductVariantPriceHistory
+
+	_, err := r.db.NewUpdate().
+		Model((*masterdataentity.ProductVariantPriceHistory)(nil)).
+		Set("? = ?", bun.Ident(column), now).
+		Set("updated_at = ?", now).
+		Where("id IN (?)", due).
+		Returning("*").
+		Exec(ctx, &histories)
+
+	return histories, err
+}
+
+func (r *RepositoryModule) ProductIdsWithAttributeValuesQuery(attributeId uuid.UUID, values []string) *bun.SelectQuery {
+	return r.db.NewSelect().
+		TableExpr("rel_product_variant_product_attribute AS rpa").
+		Column("rpa.product_id").
+		Where("rpa.product_attribute_id = ?", attributeId).
+		Where("rpa.value IN (?)", bun.In(values)).
+		Where("?", buncrud.NotDeleted[masterdataentity.RelProductVariantProductAttribute](r.db, "rpa"))
+}
+
+func (r *RepositoryModule) FindAttributeFacets(ctx context.Context, options *crud.QueryOptions, attributeIds []uuid.UUID, exclude bool) ([]*AttributeFacet, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindAttributeFacets", map[string]any{
+		"attributeIds": attributeIds,
+		"exclude":      exclude,
+	})
+	defer span.End()
+
+	var facets []*AttributeFacet
+	if len(attributeIds) == 0 && !exclude {
+		return facets, nil
+	}
+
+	// Facets count over every matching product, so the page and order of the options do not apply.
+	filterOptions := &crud.QueryOptions{}
+	if options != nil {
+		filterOptions.Filters = options.Filters
+	}
+	productIdsQuery := r.productsRepo.QueryBuilder(ctx, filterOptions).ColumnExpr("product.id")
+
+	query := r.db.NewSelect().
+		TableExpr("rel_product_variant_product_attribute AS rpa").
+		Column("rpa.product_attribute_id", "rpa.value").
+		ColumnExpr("COUNT(DISTINCT rpa.product_id) AS count").
+		Join("JOIN product_attribute AS pa ON pa.id = rpa.product_attribute_id AND ?", buncrud.NotDeleted[masterdataentity.ProductAttribute](r.db, "pa")).
+		Where("?", buncrud.NotDeleted[masterdataentity.RelProductVariantProductAttribute](r.db, "rpa")).
+		Where("rpa.product_id IN (?)", productIdsQuery).
+		Group("rpa.product_attribute_id", "rpa.value").
+		OrderExpr("rpa.product_attribute_id ASC, count DESC, rpa.value ASC")
+
+	if exclude {
+		if len(attributeIds) > 0 {
+			query.Where("rpa.product_attribute_id NOT IN (?)", bun.In(attributeIds))
+		}
+	} else {
+		query.Where("rpa.product_attribute_id IN (?)", bun.In(attributeIds))
+	}
+
+	err := query.Scan(ctx, &facets)
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+func (r *RepositoryModule) FindAggregateByID(ctx context.Context, id uuid.UUID) (*masterdataentity.Product, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindAggregateByID", map[string]any{
+		"id": id,
+	})
+	defer span.End()
+
+	product := new(masterdataentity.Product)
+	err := r.db.NewSelect().
+		Model(product).
+		Relation("Translations").
+		Relation("Variants").
+		Relation("Variants.Attributes").
+		Relation("Variants.Prices").
+		Where("product.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, crud.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (r *RepositoryModule) FindVariantSkusWithPrefixes(ctx context.Context, prefixes []string) ([]string, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindVariantSkusWithPrefixes", map[string]any{
+		"prefixes": prefixes,
+	})
+	defer span.End()
+
+	var skus []string
+	if len(prefixes) == 0 {
+		return skus, nil
+	}
+
+	err := r.db.NewSelect().
+		Model((*masterdataentity.ProductVariant)(nil)).
+		Column("sku").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, prefix := range prefixes {
+				q = q.WhereOr("starts_with(sku, ?)", prefix)
+			}
+			return q
+		}).
+		Scan(ctx, &skus)
+	if err != nil {
+		return nil, err
+	}
+
+	return skus, nil
+}
+
+func (r *RepositoryModule) Bundle() buncrud.BaseRepository[masterdataentity.ProductBundle] {
+	return r.bundlesRepo
+}
+
+func (r *RepositoryModule) BundleComponent() buncrud.BaseRepository[masterdataentity.ProductBundleComponent] {
+	return r.bundleComponentsRepo
+}
+
+func (r *RepositoryModule) ReplaceBundleComponents(ctx context.Context, bundleId uuid.UUID, components []*masterdataentity.ProductBundleComponent) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/ReplaceBundleComponents", map[string]any{
+		"bundleId": bundleId,
+	})
+	defer span.End()
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.ProductBundleComponent)(nil)).
+		Where("product_bundle_id = ?", bundleId).
+		Exec(ctx)
+	if err != nil || len(components) == 0 {
+		return err
+	}
+
+	_, err = r.bundleComponentsRepo.CreateBulk(ctx, components)
+	return err
+}
+
+func (r *RepositoryModule) LockVariants(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/LockVariants", map[string]any{
+		"ids": ids,
+	})
+	defer span.End()
+
+	// The rows are locked in the order of their ids, so that concurrent transactions can not deadlock.
+	var lockedIds []uuid.UUID
+	return r.db.NewSelect().
+		Model((*masterdataentity.ProductVariant)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In(ids)).
+		OrderExpr("id ASC").
+		For("UPDATE").
+		Scan(ctx, &lockedIds)
+}
+
+func (r *RepositoryModule) DeleteBundle(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/DeleteBundle", map[string]any{
+		"id": id,
+	})
+	defer span.End()
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.ProductBundleComponent)(nil)).
+		Where("product_bundle_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.NewDelete().
+		Model((*masterdataentity.ProductBundle)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return crud.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RepositoryModule) Media() buncrud.BaseRepository[masterdataentity.ProductMedia] {
+	return r.mediaRepo
+}
+
+func (r *RepositoryModule) NextMediaPosition(ctx context.Context, productId uuid.UUID) (int, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/NextMediaPosition", map[string]any{
+		"productId": productId,
+	})
+	defer span.End()
+
+	var position int
+	err := r.db.NewSelect().
+		Model((*masterdataentity.ProductMedia)(nil)).
+		ColumnExpr("COALESCE(MAX(position) + 1, 0)").
+		Where("product_id = ?", productId).
+		Scan(ctx, &position)
+	if err != nil {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+func (r *RepositoryModule) FindOrphanMedia(ctx context.Context, limit int) ([]*masterdataentity.ProductMedia, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindOrphanMedia", map[string]any{
+		"limit": limit,
+	})
+	defer span.End()
+
+	// The query of the product model leaves the soft deleted products out by itself.
+	liveProducts := r.db.NewSelect().
+		Model((*masterdataentity.Product)(nil)).
+		ColumnExpr("1").
+		Where("product.id = product_media.product_id")
+
+	var media []*masterdataentity.ProductMedia
+	err := r.db.NewSelect().
+		Model(&media).
+		Where("NOT EXISTS (?)", liveProducts).
+		OrderExpr("product_media.created_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+func (r *RepositoryModule) DeleteMediaIn(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/DeleteMediaIn", map[string]any{
+		"ids": ids,
+	})
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.ProductMedia)(nil)).
+		Where("id IN (?)", bun.In(ids)).
+		Exec(ctx)
+	return err
+}
+
+func (r *RepositoryModule) Tag() buncrud.BaseRepository[masterdataentity.ProductTag] {
+	return r.tagsRepo
+}
+
+func (r *RepositoryModule) FindOrCreateTags(ctx context.Context, tags []*masterdataentity.ProductTag) ([]*masterdataentity.ProductTag, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindOrCreateTags", map[string]any{
+		"count": len(tags),
+	})
+	defer span.End()
+
+	var result []*masterdataentity.ProductTag
+	if len(tags) == 0 {
+		return result, nil
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&tags).
+		On("CONFLICT (slug) DO NOTHING").
+		Returning("NULL").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+
+	err = r.db.NewSelect().
+		Model(&result).
+		Where("slug IN (?)", bun.In(slugs)).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *RepositoryModule) AddProductTags(ctx context.Context, productIds []uuid.UUID, tagIds []uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/AddProductTags", map[string]any{
+		"productIds": productIds,
+		"tagIds":     tagIds,
+	})
+	defer span.End()
+
+	if len(productIds) == 0 || len(tagIds) == 0 {
+		return nil
+	}
+
+	links := make([]*masterdataentity.RelProductTag, 0, len(productIds)*len(tagIds))
+	for _, productId := range productIds {
+		for _, tagId := range tagIds {
+			links = append(links, &masterdataentity.RelProductTag{
+				Id:        uuid.New(),
+				ProductId: productId,
+				TagId:     tagId,
+			})
+		}
+	}
+
+	_, err := r.db.NewInsert().
+		Model(&links).
+		On("CONFLICT (product_id, product_tag_id) DO NOTHING").
+		Returning("NULL").
+		Exec(ctx)
+	return err
+}
+
+func (r *RepositoryModule) RemoveProductTags(ctx context.Context, productIds []uuid.UUID, tagIds []uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/RemoveProductTags", map[string]any{
+		"productIds": productIds,
+		"tagIds":     tagIds,
+	})
+	defer span.End()
+
+	if len(productIds) == 0 || len(tagIds) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewDelete().
+		Model((*masterdataentity.RelProductTag)(nil)).
+		Where("product_id IN (?)", bun.In(productIds)).
+		Where("product_tag_id IN (?)", bun.In(tagIds)).
+		Exec(ctx)
+	return err
+}
+
+func (r *RepositoryModule) RefreshTagUsageCounts(ctx context.Context, tagIds []uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/RefreshTagUsageCounts", map[string]any{
+		"tagIds": tagIds,
+	})
+	defer span.End()
+
+	if len(tagIds) == 0 {
+		return nil
+	}
+
+	_, err := r.db.NewUpdate().
+		Model((*masterdataentity.ProductTag)(nil)).
+		Set("usage_count = (SELECT COUNT(*) FROM rel_product_tag AS rpt WHERE rpt.product_tag_id = product_tag.id)").
+		Set("updated_at = current_timestamp").
+		Where("id IN (?)", bun.In(tagIds)).
+		Exec(ctx)
+	return err
+}
+
+func (r *RepositoryModule) FindTagsByPrefix(ctx context.Context, prefix string, limit int) ([]*masterdataentity.ProductTag, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindTagsByPrefix", map[string]any{
+		"prefix": prefix,
+		"limit":  limit,
+	})
+	defer span.End()
+
+	var tags []*masterdataentity.ProductTag
+	query := r.db.NewSelect().
+		Model(&tags).
+		OrderExpr("usage_count DESC, slug ASC").
+		Limit(limit)
+	if prefix != "" {
+		query.Where("starts_with(slug, ?)", prefix)
+	}
+
+	err := query.Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *RepositoryModule) FindProductTagsIn(ctx context.Context, productIds []uuid.UUID) ([]*masterdataentity.RelProductTag, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindProductTagsIn", map[string]any{
+		"productIds": productIds,
+	})
+	defer span.End()
+
+	var links []*masterdataentity.RelProductTag
+	if len(productIds) == 0 {
+		return links, nil
+	}
+
+	err := r.db.NewSelect().
+		Model(&links).
+		Relation("Tag").
+		Where("rel_product_tag.product_id IN (?)", bun.In(productIds)).
+		OrderExpr("tag.slug ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (r *RepositoryModule) ProductIdsWithTagQuery(slug string) *bun.SelectQuery {
+	return r.db.NewSelect().
+		TableExpr("rel_product_tag AS rpt").
+		Column("rpt.product_id").
+		Join("JOIN product_tag AS pt ON pt.id = rpt.product_tag_id").
+		Where("pt.slug = ?", slug)
+}
+
+func (r *RepositoryModule) FindRelatedProducts(ctx context.Context, productIds []uuid.UUID, options RelatedProductsOptions) ([]*RelatedProduct, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductRepository/FindRelatedProducts", map[string]any{
+		"productIds":     productIds,
+		"limit":          options.Limit,
+		"categoryWeight": options.CategoryWeight,
+		"tagWeight":      options.TagWeight,
+	})
+	defer span.End()
+
+	var related []*RelatedProduct
+	if len(productIds) == 0 || options.Limit <= 0 {
+		return related, nil
+	}
+
+	err := r.db.NewRaw(`
+		WITH scores AS (
+			SELECT s.product_id, c.product_id AS related_product_id, COUNT(DISTINCT (c.product_attribute_id, c.value))::float8 AS score
+			FROM rel_product_variant_product_attribute AS s
+			JOIN rel_product_variant_product_attribute AS c
+				ON c.product_attribute_id = s.product_attribute_id AND c.value = s.value
+				AND c.product_id <> s.product_id AND ?
+			WHERE s.product_id IN (?) AND ?
+			GROUP BY s.product_id, c.product_id
+			UNION ALL
+			SELECT s.product_id, c.product_id, COUNT(*) * ?::float8
+			FROM rel_product_category AS s
+			JOIN rel_product_category AS c ON c.category_id = s.category_id AND c.product_id <> s.product_id
+			WHERE ?::float8 <> 0 AND s.product_id IN (?)
+			GROUP BY s.product_id, c.product_id
+			UNION ALL
+			SELECT s.product_id, c.product_id, COUNT(*) * ?::float8
+			FROM rel_product_tag AS s
+			JOIN rel_product_tag AS c ON c.product_tag_id = s.product_tag_id AND c.product_id <> s.product_id
+			WHERE ?::float8 <> 0 AND s.product_id IN (?)
+			GROUP BY s.product_id, c.product_id
+		), ranked AS (
+			SELECT sc.product_id, sc.related_product_id, SUM(sc.score) AS score,
+				ROW_NUMBER() OVER (PARTITION BY sc.product_id ORDER BY SUM(sc.score) DESC, sc.related_product_id ASC) AS rank
+			FROM scores AS sc
+			JOIN product AS p ON p.id = sc.related_product_id AND ? AND p.status = ?
+			GROUP BY sc.product_id, sc.related_product_id
+			HAVING SUM(sc.score) > 0
+		)
+		SELECT product_id, related_product_id, score
+		FROM ranked
+		WHERE rank <= ?
+		ORDER BY product_id, rank`,
+		buncrud.NotDeleted[masterdataentity.RelProductVariantProductAttribute](r.db, "c"),
+		bun.In(productIds),
+		buncrud.NotDeleted[masterdataentity.RelProductVariantProductAttribute](r.db, "s"),
+		options.CategoryWeight, options.CategoryWeight, bun.In(productIds),
+		options.TagWeight, options.TagWeight, bun.In(productIds),
+		buncrud.NotDeleted[masterdataentity.Product](r.db, "p"),
+		options.Status,
+		options.Limit,
+	).Scan(ctx, &related)
+	if err != nil {
+		return nil, err
+	}
+
+	return related, nil
+}
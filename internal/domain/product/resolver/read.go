@@ -4,14 +4,78 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 
 	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/service/crud"
 )
 
-func (r *ResolverModule) FindById(ctx context.Context, id uuid.UUID) (*productdto.Product, error) {
-	return r.productUseCase.FindById(ctx, id)
+func (r *ResolverModule) FindById(ctx context.Context, id uuid.UUID, lang *string) (*productdto.Product, error) {
+	return r.productUseCase.FindById(withLanguage(ctx, lang), id)
 }
 
-func (r *ResolverModule) FindAll(ctx context.Context, qop *productdto.ProductQop) (*productdto.ProductList, error) {
-	return r.productUseCase.FindAll(ctx, qop)
+func (r *ResolverModule) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.Product, error) {
+	return r.productUseCase.FindByIds(ctx, ids)
+}
+
+func (r *ResolverModule) FindVariantsByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.ProductVariant, error) {
+	return r.productUseCase.FindVariantsByIds(ctx, ids)
+}
+
+func (r *ResolverModule) FindAll(ctx context.Context, qop *productdto.ProductQop, lang *string, search *string) (*productdto.ProductList, error) {
+	if search != nil {
+		if qop == nil {
+			qop = &productdto.ProductQop{}
+		}
+		qop.Search = search
+	}
+	return r.productUseCase.FindAll(withLanguage(ctx, lang), qop)
+}
+
+func (r *ResolverModule) FindConnection(ctx context.Context, args crud.ConnectionArgs, filters *productdto.ProductQopFilter, sorts []*crud.Sort, lang *string, search *string) (*productdto.ProductConnection, error) {
+	qop := &productdto.ProductQop{
+		Search: search,
+	}
+	if filters != nil {
+		qop.Filters = *filters
+	}
+	for _, sort := range sorts {
+		if sort != nil {
+			qop.Sorts = append(qop.Sorts, *sort)
+		}
+	}
+	return r.productUseCase.FindConnection(withLanguage(ctx, lang), qop, args)
+}
+
+func (r *ResolverModule) FindFacets(ctx context.Context, qop *productdto.ProductQop, lang *string) ([]*productdto.ProductFacet, error) {
+	return r.productUseCase.FindFacets(withLanguage(ctx, lang), qop)
+}
+
+func (r *ResolverModule) FindBundleById(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error) {
+	return r.productUseCase.FindBundleById(ctx, id)
+}
+
+func (r *ResolverModule) FindAllBundles(ctx context.Context, qop *productdto.ProductBundleQop) (*productdto.ProductBundleList, error) {
+	return r.productUseCase.FindAllBundles(ctx, qop)
+}
+
+func (r *ResolverModule) MediaURL(ctx context.Context, media *productdto.ProductMedia) (string, error) {
+	return r.productUseCase.MediaURL(ctx, media)
+}
+
+func (r *ResolverModule) SuggestTags(ctx context.Context, prefix *string, limit *int) ([]*productdto.ProductTag, error) {
+	return r.productUseCase.SuggestTags(ctx, lo.FromPtr(prefix), limit)
+}
+
+// withLanguage overrides the language requested through the Accept-Language header with the lang argument.
+// An unsupported language is ignored.
+func withLanguage(ctx context.Context, lang *string) context.Context {
+	if lang == nil {
+		return ctx
+	}
+	if normalized := language.Normalize(*lang); normalized != "" {
+		return language.WithContext(ctx, normalized)
+	}
+	return ctx
 }
@@ -7,6 +7,7 @@ import (
 
 	productdto "gobase/internal/domain/product/dto"
 	productusecase "gobase/internal/domain/product/usecase"
+	"gobase/internal/pkg/service/crud"
 )
 
 // Resolver is the interface for the product domain's GraphQL queries and mutations.
@@ -14,8 +15,32 @@ import (
 type Resolver interface {
 	Create(ctx context.Context, input productdto.CreateProductInput) (*productdto.Product, error)
 	CreateAttribute(ctx context.Context, input productdto.CreateProductAttributeInput) (*productdto.ProductAttribute, error)
-	FindById(ctx context.Context, id uuid.UUID) (*productdto.Product, error)
-	FindAll(ctx context.Context, qop *productdto.ProductQop) (*productdto.ProductList, error)
+	FindById(ctx context.Context, id uuid.UUID, lang *string) (*productdto.Product, error)
+	FindByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.Product, error)
+	FindVariantsByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.ProductVariant, error)
+	FindAll(ctx context.Context, qop *productdto.ProductQop, lang *string, search *string) (*productdto.ProductList, error)
+	FindConnection(ctx context.Context, args crud.ConnectionArgs, filters *productdto.ProductQopFilter, sorts []*crud.Sort, lang *string, search *string) (*productdto.ProductConnection, error)
+	ImportProducts(ctx context.Context, input productdto.ImportProductsInput) (*productdto.ImportProductsResult, error)
+	SetVariantPrices(ctx context.Context, input productdto.SetProductVariantPricesInput) ([]*productdto.ProductVariantPrice, error)
+	SchedulePrice(ctx context.Context, input productdto.ScheduleProductVariantPriceInput) (*productdto.ProductVariantPriceHistory, error)
+	TransitionStatus(ctx context.Context, id uuid.UUID, transition productdto.ProductStatusTransition) (*productdto.Product, error)
+	SetTranslations(ctx context.Context, input productdto.SetProductTranslationsInput) ([]*productdto.ProductTranslation, error)
+	FindFacets(ctx context.Context, qop *productdto.ProductQop, lang *string) ([]*productdto.ProductFacet, error)
+	Clone(ctx context.Context, id uuid.UUID, overrides *productdto.CloneProductOverrides) (*productdto.Product, error)
+	FindBundleById(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error)
+	FindAllBundles(ctx context.Context, qop *productdto.ProductBundleQop) (*productdto.ProductBundleList, error)
+	CreateBundle(ctx context.Context, input productdto.CreateProductBundleInput) (*productdto.ProductBundle, error)
+	UpdateBundle(ctx context.Context, input productdto.UpdateProductBundleInput) (*productdto.ProductBundle, error)
+	DeleteBundle(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error)
+	UploadMedia(ctx context.Context, input productdto.UploadProductMediaInput) (*productdto.ProductMedia, error)
+	UpdateMedia(ctx context.Context, input productdto.UpdateProductMediaInput) (*productdto.ProductMedia, error)
+	DeleteMedia(ctx context.Context, id uuid.UUID) (*productdto.ProductMedia, error)
+	MediaURL(ctx context.Context, media *productdto.ProductMedia) (string, error)
+	AddTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error)
+	RemoveTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error)
+	SuggestTags(ctx context.Context, prefix *string, limit *int) ([]*productdto.ProductTag, error)
+	SubscribeCreated(ctx context.Context) (<-chan *productdto.Product, error)
+	SubscribeUpdated(ctx context.Context, id uuid.UUID) (<-chan *productdto.Product, error)
 }
 
 // ResolverModule is the implementation of the Resolver interface.
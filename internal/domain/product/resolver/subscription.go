@@ -0,0 +1,17 @@
+package productresolver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	productdto "gobase/internal/domain/product/dto"
+)
+
+func (r *ResolverModule) SubscribeCreated(ctx context.Context) (<-chan *productdto.Product, error) {
+	return r.productUseCase.SubscribeProductEvents(ctx, productdto.ProductEventCreated, nil), nil
+}
+
+func (r *ResolverModule) SubscribeUpdated(ctx context.Context, id uuid.UUID) (<-chan *productdto.Product, error) {
+	return r.productUseCase.SubscribeProductEvents(ctx, productdto.ProductEventUpdated, &id), nil
+}
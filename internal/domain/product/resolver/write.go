@@ -3,6 +3,8 @@ package productresolver
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	productdto "gobase/internal/domain/product/dto"
 )
 
@@ -13,3 +15,62 @@ func (r *ResolverModule) Create(ctx context.Context, input productdto.CreateProd
 func (r *ResolverModule) CreateAttribute(ctx context.Context, input productdto.CreateProductAttributeInput) (*productdto.ProductAttribute, error) {
 	return r.productUseCase.CreateAttribute(ctx, input)
 }
+
+func (r *ResolverModule) ImportProducts(ctx context.Context, input productdto.ImportProductsInput) (*productdto.ImportProductsResult, error) {
+	return r.productUseCase.ImportProducts(ctx, input)
+}
+
+func (r *ResolverModule) SetVariantPrices(ctx context.Context, input productdto.SetProductVariantPricesInput) ([]*productdto.ProductVariantPrice, error) {
+	return r.productUseCase.SetVariantPrices(ctx, input)
+}
+
+func (r *ResolverModule) SchedulePrice(ctx context.Context, input productdto.ScheduleProductVariantPriceInput) (*productdto.ProductVariantPriceHistory, error) {
+	return r.productUseCase.SchedulePrice(ctx, input)
+}
+
+func (r *ResolverModule) TransitionStatus(ctx context.Context, id uuid.UUID, transition productdto.ProductStatusTransition) (*productdto.Product, error) {
+	return r.productUseCase.TransitionStatus(ctx, id, transition)
+}
+
+func (r *ResolverModule) SetTranslations(ctx context.Context, input productdto.SetProductTranslationsInput) ([]*productdto.ProductTranslation, error) {
+	return r.productUseCase.SetTranslations(ctx, input)
+}
+
+func (r *ResolverModule) Clone(ctx context.Context, id uuid.UUID, overrides *productdto.CloneProductOverrides) (*productdto.Product, error) {
+	return r.productUseCase.Clone(ctx, productdto.CloneProductInput{
+		ID:        id,
+		Overrides: overrides,
+	})
+}
+
+func (r *ResolverModule) CreateBundle(ctx context.Context, input productdto.CreateProductBundleInput) (*productdto.ProductBundle, error) {
+	return r.productUseCase.CreateBundle(ctx, input)
+}
+
+func (r *ResolverModule) UpdateBundle(ctx context.Context, input productdto.UpdateProductBundleInput) (*productdto.ProductBundle, error) {
+	return r.productUseCase.UpdateBundle(ctx, input)
+}
+
+func (r *ResolverModule) DeleteBundle(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error) {
+	return r.productUseCase.DeleteBundle(ctx, id)
+}
+
+func (r *ResolverModule) UploadMedia(ctx context.Context, input productdto.UploadProductMediaInput) (*productdto.ProductMedia, error) {
+	return r.productUseCase.UploadMedia(ctx, input)
+}
+
+func (r *ResolverModule) UpdateMedia(ctx context.Context, input productdto.UpdateProductMediaInput) (*productdto.ProductMedia, error) {
+	return r.productUseCase.UpdateMedia(ctx, input)
+}
+
+func (r *ResolverModule) DeleteMedia(ctx context.Context, id uuid.UUID) (*productdto.ProductMedia, error) {
+	return r.productUseCase.DeleteMedia(ctx, id)
+}
+
+func (r *ResolverModule) AddTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error) {
+	return r.productUseCase.AddTags(ctx, input)
+}
+
+func (r *ResolverModule) RemoveTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error) {
+	return r.productUseCase.RemoveTags(ctx, input)
+}
@@ -0,0 +1,266 @@
+package productusecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	productmapper "gobase/internal/domain/product/mapper"
+	productrepository "gobase/internal/domain/product/repository"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+func (m *UseCaseModule) FindBundleById(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/FindBundleById")
+	defer span.End()
+
+	bundleEntity, err := m.repository.Bundle().FindByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductBundle")
+		}
+		return nil, err
+	}
+
+	return productmapper.ProductBundleEntityToDTO(bundleEntity), nil
+}
+
+func (m *UseCaseModule) FindAllBundles(ctx context.Context, qop *productdto.ProductBundleQop) (*productdto.ProductBundleList, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/FindAllBundles")
+	defer span.End()
+
+	options := crud.NewQueryOptions()
+
+	if qop != nil {
+		qop.WithAllowedSorts([]string{"created_at", "updated_at"})
+		options = qop.ToQueryOptions()
+	}
+
+	entityResult, err := m.repository.Bundle().FindAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleDTOs := make([]*productdto.ProductBundle, len(entityResult.Items))
+	for i, b := range entityResult.Items {
+		bundleDTOs[i] = productmapper.ProductBundleEntityToDTO(&b)
+	}
+
+	return &productdto.ProductBundleList{
+		Items:      bundleDTOs,
+		Pagination: entityResult.Pagination,
+	}, nil
+}
+
+// CreateBundle turns a variant into a bundle of other variants. The variant must not already be a bundle
+// or a component of one.
+func (m *UseCaseModule) CreateBundle(ctx context.Context, input productdto.CreateProductBundleInput) (*productdto.ProductBundle, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/CreateBundle")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleEntity := input.ToEntity()
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		err := m.lockBundleVariants(ctx, txRepo, input.VariantID, bundleEntity.Components)
+		if err != nil {
+			return err
+		}
+
+		_, err = txRepo.Variant().FindByID(ctx, input.VariantID.String())
+		if err != nil {
+			if errors.Is(err, crud.ErrNotFound) {
+				return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductVariant")
+			}
+			return err
+		}
+
+		bundles, err := txRepo.Bundle().FindIn(ctx, "product_variant_id", []any{input.VariantID}, &crud.QueryOptions{})
+		if err != nil {
+			return err
+		}
+		if len(bundles) > 0 {
+			return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorVariantAlreadyBundle", nil)
+		}
+
+		components, err := txRepo.BundleComponent().FindIn(ctx, "product_variant_id", []any{input.VariantID}, &crud.QueryOptions{})
+		if err != nil {
+			return err
+		}
+		if len(components) > 0 {
+			return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorBundleVariantIsComponent", nil)
+		}
+
+		err = m.validateBundleComponents(ctx, txRepo, input.VariantID, bundleEntity.Components)
+		if err != nil {
+			return err
+		}
+
+		_, err = txRepo.Bundle().Create(ctx, bundleEntity)
+		if err != nil {
+			return err
+		}
+
+		_, err = txRepo.BundleComponent().CreateBulk(ctx, bundleEntity.Components)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return productmapper.ProductBundleEntityToDTO(bundleEntity), nil
+}
+
+// UpdateBundle changes the price of a bundle and replaces its components when they are given.
+func (m *UseCaseModule) UpdateBundle(ctx context.Context, input productdto.UpdateProductBundleInput) (*productdto.ProductBundle, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/UpdateBundle")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundleEntity *masterdataentity.ProductBundle
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		bundleEntity, err = txRepo.Bundle().FindByID(ctx, input.ID.String())
+		if err != nil {
+			if errors.Is(err, crud.ErrNotFound) {
+				return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductBundle")
+			}
+			return err
+		}
+
+		if input.Components != nil {
+			componentEntities := lo.Map(input.Components, func(component productdto.ProductBundleComponentInput, _ int) *masterdataentity.ProductBundleComponent {
+				return component.ToEntity(bundleEntity.Id)
+			})
+
+			err = m.lockBundleVariants(ctx, txRepo, bundleEntity.VariantId, componentEntities)
+			if err != nil {
+				return err
+			}
+
+			err = m.validateBundleComponents(ctx, txRepo, bundleEntity.VariantId, componentEntities)
+			if err != nil {
+				return err
+			}
+
+			err = txRepo.ReplaceBundleComponents(ctx, bundleEntity.Id, componentEntities)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.Price != nil {
+			price := input.Price.Round()
+			bundleEntity.Price = price.Amount
+			bundleEntity.Currency = price.Currency
+		}
+		bundleEntity.UpdatedAt = time.Now()
+
+		_, err = txRepo.Bundle().Update(ctx, bundleEntity)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return productmapper.ProductBundleEntityToDTO(bundleEntity), nil
+}
+
+// DeleteBundle deletes a bundle with its components. The variant it was sold as is kept.
+func (m *UseCaseModule) DeleteBundle(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/DeleteBundle")
+	defer span.End()
+
+	var bundleEntity *masterdataentity.ProductBundle
+
+	err := m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		var err error
+		bundleEntity, err = txRepo.Bundle().FindByID(ctx, id.String())
+		if err != nil {
+			return err
+		}
+
+		return txRepo.DeleteBundle(ctx, id)
+	})
+
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductBundle")
+		}
+		return nil, err
+	}
+
+	return productmapper.ProductBundleEntityToDTO(bundleEntity), nil
+}
+
+// lockBundleVariants locks the variant of a bundle and its components, so that no concurrent transaction can make
+// one of the components a bundle, or the variant a component, between their validation and the write.
+func (m *UseCaseModule) lockBundleVariants(ctx context.Context, repo productrepository.Repository, bundleVariantId uuid.UUID, components []*masterdataentity.ProductBundleComponent) error {
+	variantIds := lo.Map(components, func(component *masterdataentity.ProductBundleComponent, _ int) uuid.UUID {
+		return component.VariantId
+	})
+	return repo.LockVariants(ctx, lo.Uniq(append(variantIds, bundleVariantId)))
+}
+
+// validateBundleComponents checks that a bundle has components, that they exist, that each of them is only listed once
+// and that none of them is the bundle's own variant or a bundle itself.
+func (m *UseCaseModule) validateBundleComponents(ctx context.Context, repo productrepository.Repository, bundleVariantId uuid.UUID, components []*masterdataentity.ProductBundleComponent) error {
+	if len(components) == 0 {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorBundleWithoutComponents", nil)
+	}
+
+	variantIds := lo.Map(components, func(component *masterdataentity.ProductBundleComponent, _ int) uuid.UUID {
+		return component.VariantId
+	})
+	if len(lo.Uniq(variantIds)) != len(variantIds) || lo.Contains(variantIds, bundleVariantId) {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorDuplicateBundleComponent", nil)
+	}
+
+	anyVariantIds := lo.ToAnySlice(variantIds)
+
+	variants, err := repo.Variant().FindIn(ctx, "id", anyVariantIds, &crud.QueryOptions{})
+	if err != nil {
+		return err
+	}
+	if len(variants) != len(variantIds) {
+		return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductVariant")
+	}
+
+	bundles, err := repo.Bundle().FindIn(ctx, "product_variant_id", anyVariantIds, &crud.QueryOptions{})
+	if err != nil {
+		return err
+	}
+	if len(bundles) > 0 {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorBundleComponentIsBundle", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package productusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// cloneSkuSuffix is appended to the SKUs of the cloned variants. Further clones of the same SKU are numbered,
+// e.g. ABC-COPY, ABC-COPY-2, ABC-COPY-3.
+const cloneSkuSuffix = "-COPY"
+
+// Clone creates a draft copy of a product with its translations, variants, attribute values and price lists.
+// Scheduled prices are not copied.
+func (m *UseCaseModule) Clone(ctx context.Context, input productdto.CloneProductInput) (*productdto.Product, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/Clone")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloneEntity *masterdataentity.Product
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		productEntity, err := txRepo.FindAggregateByID(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, crud.ErrNotFound) {
+				return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "Product")
+			}
+			return err
+		}
+
+		skus := lo.Map(productEntity.Variants, func(variant *masterdataentity.ProductVariant, _ int) string {
+			return variant.SKU
+		})
+		takenSkus, err := txRepo.FindVariantSkusWithPrefixes(ctx, lo.Map(skus, func(sku string, _ int) string {
+			return sku + cloneSkuSuffix
+		}))
+		if err != nil {
+			return err
+		}
+
+		cloneEntity = cloneProductEntity(productEntity, input.Overrides, lo.SliceToMap(takenSkus, func(sku string) (string, bool) {
+			return sku, true
+		}))
+
+		return m.insertProducts(ctx, tx, []*masterdataentity.Product{cloneEntity})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return productmapper.ProductEntityToDTO(cloneEntity), nil
+}
+
+// cloneProductEntity deep-copies a product under new ids. takenSkus is updated with the SKUs given to the clone.
+func cloneProductEntity(product *masterdataentity.Product, overrides *productdto.CloneProductOverrides, takenSkus map[string]bool) *masterdataentity.Product {
+	now := time.Now()
+
+	clone := &masterdataentity.Product{
+		Id:          uuid.New(),
+		Name:        product.Name,
+		Description: product.Description,
+		Status:      string(productdto.ProductStatusDraft),
+		Version:     1,
+		CreatedAt:   now,
+	}
+
+	if overrides != nil {
+		if overrides.Name != nil {
+			clone.Name = *overrides.Name
+		}
+		if overrides.Description != nil {
+			clone.Description = *overrides.Description
+		}
+	}
+
+	for _, translation := range product.Translations {
+		clone.Translations = append(clone.Translations, &masterdataentity.ProductTranslation{
+			Id:          uuid.New(),
+			ProductId:   clone.Id,
+			Language:    translation.Language,
+			Name:        translation.Name,
+			Description: translation.Description,
+			CreatedAt:   now,
+		})
+	}
+
+	for _, variant := range product.Variants {
+		variantClone := &masterdataentity.ProductVariant{
+			Id:              uuid.New(),
+			ProductId:       clone.Id,
+			Name:            variant.Name,
+			SKU:             cloneSku(variant.SKU, takenSkus),
+			Price:           variant.Price,
+			DiscountedPrice: variant.DiscountedPrice,
+			Currency:        variant.Currency,
+			Version:         1,
+			CreatedAt:       now,
+		}
+
+		for _, attribute := range variant.Attributes {
+			variantClone.Attributes = append(variantClone.Attributes, &masterdataentity.RelProductVariantProductAttribute{
+				Id:          uuid.New(),
+				ProductId:   clone.Id,
+				VariantId:   variantClone.Id,
+				AttributeId: attribute.AttributeId,
+				Value:       attribute.Value,
+				CreatedAt:   now,
+			})
+		}
+
+		for _, price := range variant.Prices {
+			variantClone.Prices = append(variantClone.Prices, &masterdataentity.ProductVariantPrice{
+				Id:              uuid.New(),
+				VariantId:       variantClone.Id,
+				Currency:        price.Currency,
+				Price:           price.Price,
+				DiscountedPrice: price.DiscountedPrice,
+				CreatedAt:       now,
+			})
+		}
+
+		clone.Variants = append(clone.Variants, variantClone)
+	}
+
+	return clone
+}
+
+// cloneSku returns the first SKU following the suffix rule that is not taken yet, and marks it as taken.
+func cloneSku(sku string, takenSkus map[string]bool) string {
+	candidate := sku + cloneSkuSuffix
+	for n := 2; takenSkus[candidate]; n++ {
+		candidate = fmt.Sprintf("%s%s-%d", sku, cloneSkuSuffix, n)
+	}
+	takenSkus[candidate] = true
+	return candidate
+}
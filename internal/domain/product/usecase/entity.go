@@ -0,0 +1,68 @@
+package productusecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	productdto "gobase/internal/domain/product/dto"
+	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// FindByIds finds the products of the ids in one query. The products are returned in the order of the ids, with nil
+// for the ids of the products that do not exist, as the federation gateway resolves entity representations.
+func (m *UseCaseModule) FindByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.Product, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/FindByIds")
+	defer span.End()
+
+	productEntities, err := m.repository.Product().FindIn(ctx, "id", lo.ToAnySlice(lo.Uniq(ids)), &crud.QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	productsById := make(map[uuid.UUID]*productdto.Product, len(productEntities))
+	for _, productEntity := range productEntities {
+		product := productmapper.ProductEntityToDTO(productEntity)
+		productsById[product.ID] = product
+	}
+
+	err = m.translateProducts(ctx, lo.Values(productsById))
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*productdto.Product, len(ids))
+	for i, id := range ids {
+		products[i] = productsById[id]
+	}
+
+	return products, nil
+}
+
+// FindVariantsByIds finds the product variants of the ids in one query, in the order of the ids with nil for the ids
+// of the variants that do not exist.
+func (m *UseCaseModule) FindVariantsByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.ProductVariant, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/FindVariantsByIds")
+	defer span.End()
+
+	variantEntities, err := m.repository.Variant().FindIn(ctx, "id", lo.ToAnySlice(lo.Uniq(ids)), &crud.QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	variantsById := make(map[uuid.UUID]*productdto.ProductVariant, len(variantEntities))
+	for _, variantEntity := range variantEntities {
+		variant := productmapper.ProductVariantEntityToDTO(variantEntity)
+		variantsById[variant.ID] = variant
+	}
+
+	variants := make([]*productdto.ProductVariant, len(ids))
+	for i, id := range ids {
+		variants[i] = variantsById[id]
+	}
+
+	return variants, nil
+}
@@ -0,0 +1,70 @@
+package productusecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	productdto "gobase/internal/domain/product/dto"
+	productrepository "gobase/internal/domain/product/repository"
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// FindFacets counts the products matching the qop per attribute value. The values of an attribute that is
+// filtered on are counted without its own filter, so they keep showing the alternatives to the selected values.
+func (m *UseCaseModule) FindFacets(ctx context.Context, qop *productdto.ProductQop) ([]*productdto.ProductFacet, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/FindFacets")
+	defer span.End()
+
+	lang := language.FromContext(ctx)
+
+	var filteredAttributeIds []uuid.UUID
+	if qop != nil {
+		for _, attributeValue := range qop.Filters.AttributeValues {
+			if attributeValue != nil && len(attributeValue.Values) > 0 {
+				filteredAttributeIds = append(filteredAttributeIds, attributeValue.AttributeID)
+			}
+		}
+	}
+
+	facets, err := m.repository.FindAttributeFacets(ctx, m.queryOptions(ctx, qop, lang, uuid.Nil), filteredAttributeIds, true)
+	if err != nil {
+		return nil, err
+	}
+
+	counted := make(map[uuid.UUID]bool, len(filteredAttributeIds))
+	for _, attributeId := range filteredAttributeIds {
+		if counted[attributeId] {
+			continue
+		}
+		counted[attributeId] = true
+
+		attributeFacets, err := m.repository.FindAttributeFacets(ctx, m.queryOptions(ctx, qop, lang, attributeId), []uuid.UUID{attributeId}, false)
+		if err != nil {
+			return nil, err
+		}
+		facets = append(facets, attributeFacets...)
+	}
+
+	return groupAttributeFacets(facets), nil
+}
+
+// groupAttributeFacets groups the counted values by attribute, keeping the order they were counted in.
+func groupAttributeFacets(facets []*productrepository.AttributeFacet) []*productdto.ProductFacet {
+	productFacets := []*productdto.ProductFacet{}
+	byAttribute := make(map[uuid.UUID]*productdto.ProductFacet)
+	for _, facet := range facets {
+		productFacet, ok := byAttribute[facet.AttributeId]
+		if !ok {
+			productFacet = &productdto.ProductFacet{AttributeID: facet.AttributeId}
+			byAttribute[facet.AttributeId] = productFacet
+			productFacets = append(productFacets, productFacet)
+		}
+		productFacet.Values = append(productFacet.Values, &productdto.ProductFacetValue{
+			Value: facet.Value,
+			Count: facet.Count,
+		})
+	}
+	return productFacets
+}
@@ -0,0 +1,353 @@
+package productusecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/helper/excel"
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/helper/money"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+const (
+	importDefaultChunkSize = 100
+	importAttributeKeyPfx  = "attribute:"
+)
+
+var defaultImportHeaderMapping = []productdto.ImportProductHeaderMappingInput{
+	{Header: "name", Field: productdto.ImportProductFieldName},
+	{Header: "description", Field: productdto.ImportProductFieldDescription},
+	{Header: "sku", Field: productdto.ImportProductFieldSku},
+	{Header: "price", Field: productdto.ImportProductFieldPrice},
+	{Header: "discounted_price", Field: productdto.ImportProductFieldDiscountedPrice},
+	{Header: "currency", Field: productdto.ImportProductFieldCurrency},
+}
+
+// importProductGroup holds the rows of an imported file that make up a single product.
+type importProductGroup struct {
+	input     productdto.CreateProductInput
+	rows      []excel.ImportRow
+	rowErrors map[int]string
+	err       string
+}
+
+func (g *importProductGroup) rejected() bool {
+	return g.err != "" || len(g.rowErrors) > 0
+}
+
+func (m *UseCaseModule) ImportProducts(ctx context.Context, input productdto.ImportProductsInput) (*productdto.ImportProductsResult, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/ImportProducts", map[string]any{
+		"filename": input.File.Filename,
+		"dryRun":   input.DryRun,
+	})
+	defer span.End()
+
+	err := m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	lang := language.FromContext(ctx)
+
+	format := excel.FileFormat(strings.ToLower(input.Format))
+	if format == "" {
+		format = excel.FileFormatFromFilename(input.File.Filename)
+	}
+
+	headerMapping := input.HeaderMapping
+	if len(headerMapping) == 0 {
+		headerMapping = defaultImportHeaderMapping
+	}
+	for _, mapping := range headerMapping {
+		if mapping.Field == productdto.ImportProductFieldAttribute && mapping.AttributeID == uuid.Nil {
+			return nil, helper.NewLocalizedErr(m.localizer, lang, helper.ErrorKindBadRequest, "ErrorFieldRequired", map[string]interface{}{
+				"FieldName": helper.MessageParam("AttributeId"),
+			})
+		}
+	}
+
+	imported, err := m.excel.Import(input.File.File, excel.ImportOpts{
+		Format:    format,
+		SheetName: input.SheetName,
+		Mapping:   buildImportMapping(headerMapping),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	currency := input.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	groups := m.groupImportRows(lang, imported.Rows, headerMapping, currency)
+	for _, group := range groups {
+		if group.rejected() {
+			continue
+		}
+		if err := m.sp.TransformAndValidateByTag(ctx, &group.input); err != nil {
+			group.err = err.Error()
+		}
+	}
+
+	result := &productdto.ImportProductsResult{
+		DryRun:    input.DryRun,
+		TotalRows: len(imported.Rows),
+		Errors:    []*productdto.ImportProductRowError{},
+	}
+
+	validGroups := lo.Filter(groups, func(group *importProductGroup, _ int) bool {
+		return !group.rejected()
+	})
+	result.ValidRows = lo.SumBy(validGroups, func(group *importProductGroup) int {
+		return len(group.rows)
+	})
+
+	if !input.DryRun {
+		chunkSize := input.ChunkSize
+		if chunkSize <= 0 {
+			chunkSize = importDefaultChunkSize
+		}
+
+		for _, chunk := range lo.Chunk(validGroups, chunkSize) {
+			productEntities := lo.Map(chunk, func(group *importProductGroup, _ int) *masterdataentity.Product {
+				return group.input.ToEntity(true)
+			})
+
+			err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				return m.insertProducts(ctx, tx, productEntities)
+			})
+			if err != nil {
+				// The cause may hold SQL and driver details, so it is only logged.
+				log.Error().Err(err).Int("products", len(chunk)).Msg("failed to save imported products")
+				message := m.localizer.Localize(lang, "ErrorImportWriteFailed", nil)
+				for _, group := range chunk {
+					group.err = message
+				}
+				continue
+			}
+
+			result.CreatedProducts += len(chunk)
+			result.ImportedRows += lo.SumBy(chunk, func(group *importProductGroup) int {
+				return len(group.rows)
+			})
+		}
+	}
+
+	var rejectedRows []excel.ImportRow
+	rejectedMessages := make(map[int]string)
+	for _, group := range groups {
+		if !group.rejected() {
+			continue
+		}
+		for _, row := range group.rows {
+			message := group.rowErrors[row.Number]
+			if message == "" {
+				message = group.err
+			}
+			if message == "" {
+				message = m.localizer.Localize(lang, "ErrorImportProductRejected", nil)
+			}
+
+			rejectedRows = append(rejectedRows, row)
+			rejectedMessages[row.Number] = message
+			result.Errors = append(result.Errors, &productdto.ImportProductRowError{
+				Row:     row.Number,
+				Message: message,
+			})
+		}
+	}
+	result.RejectedRows = len(rejectedRows)
+
+	if len(rejectedRows) > 0 {
+		report, err := m.buildImportErrorReport(lang, imported.Headers, rejectedRows, rejectedMessages)
+		if err != nil {
+			return nil, err
+		}
+		filename := fmt.Sprintf("product-import-errors-%s.xlsx", time.Now().Format("20060102150405"))
+		result.ErrorReport = &report
+		result.ErrorReportFilename = &filename
+	}
+
+	return result, nil
+}
+
+// groupImportRows groups the imported rows into products. Rows sharing the same product name belong to the
+// same product, and a row without a product name continues the product of the previous row.
+func (m *UseCaseModule) groupImportRows(lang string, rows []excel.ImportRow, headerMapping []productdto.ImportProductHeaderMappingInput, currency string) []*importProductGroup {
+	var groups []*importProductGroup
+	groupsByName := make(map[string]*importProductGroup)
+
+	var current *importProductGroup
+	for _, row := range rows {
+		name := row.Value(excel.HeaderKey(productdto.ImportProductFieldName))
+		if name != "" {
+			key := strings.ToLower(name)
+			group, ok := groupsByName[key]
+			if !ok {
+				group = &importProductGroup{
+					input:     productdto.CreateProductInput{Name: name},
+					rowErrors: make(map[int]string),
+				}
+				groupsByName[key] = group
+				groups = append(groups, group)
+			}
+			current = group
+		}
+
+		if current == nil {
+			current = &importProductGroup{rowErrors: make(map[int]string)}
+			groups = append(groups, current)
+		}
+		current.rows = append(current.rows, row)
+
+		if description := row.Value(excel.HeaderKey(productdto.ImportProductFieldDescription)); description != "" && current.input.Description == "" {
+			current.input.Description = description
+		}
+
+		variant, err := m.parseImportVariant(lang, row, headerMapping, currency)
+		if err != nil {
+			current.rowErrors[row.Number] = err.Error()
+			continue
+		}
+		if variant != nil {
+			current.input.Variants = append(current.input.Variants, *variant)
+		}
+	}
+
+	return groups
+}
+
+// parseImportVariant builds the variant described by a row. It returns nil when the row has no variant columns.
+// Prices are in the currency column of the row, or in the given currency when the row has none.
+func (m *UseCaseModule) parseImportVariant(lang string, row excel.ImportRow, headerMapping []productdto.ImportProductHeaderMappingInput, currency string) (*productdto.CreateProductVariantInput, error) {
+	sku := row.Value(excel.HeaderKey(productdto.ImportProductFieldSku))
+	price := row.Value(excel.HeaderKey(productdto.ImportProductFieldPrice))
+	discountedPrice := row.Value(excel.HeaderKey(productdto.ImportProductFieldDiscountedPrice))
+
+	if sku == "" && price == "" && discountedPrice == "" {
+		return nil, nil
+	}
+
+	variant := &productdto.CreateProductVariantInput{
+		Sku: sku,
+	}
+
+	if rowCurrency := row.Value(excel.HeaderKey(productdto.ImportProductFieldCurrency)); rowCurrency != "" {
+		currency = rowCurrency
+	}
+
+	amount, err := m.parseImportNumber(lang, price, "Price")
+	if err != nil {
+		return nil, err
+	}
+	variant.Price = money.New(amount, currency)
+
+	if discountedPrice != "" {
+		discountedAmount, err := m.parseImportNumber(lang, discountedPrice, "DiscountedPrice")
+		if err != nil {
+			return nil, err
+		}
+		variant.DiscountedPrice = &discountedAmount
+	}
+
+	for _, mapping := range headerMapping {
+		if mapping.Field != productdto.ImportProductFieldAttribute {
+			continue
+		}
+		value := row.Value(importAttributeKey(mapping))
+		if value == "" {
+			continue
+		}
+		variant.Attributes = append(variant.Attributes, productdto.CreateProductAttributeValueInput{
+			ID:    mapping.AttributeID,
+			Value: value,
+		})
+	}
+
+	return variant, nil
+}
+
+func (m *UseCaseModule) parseImportNumber(lang string, value string, fieldName string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+
+	number, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, helper.NewLocalizedErr(m.localizer, lang, helper.ErrorKindBadRequest, "ErrorStringNotValidNumber", map[string]interface{}{
+			"StringName": helper.MessageParam(fieldName),
+		})
+	}
+
+	return number, nil
+}
+
+// buildImportErrorReport builds a base64 encoded workbook with the rejected rows and their error messages.
+func (m *UseCaseModule) buildImportErrorReport(lang string, headers []string, rows []excel.ImportRow, messages map[int]string) (string, error) {
+	table := excel.NewTableUnit()
+
+	headerCells := []*excel.HeaderCell{
+		excel.NewHeaderCell(m.localizer.Localize(lang, "ImportRowNumber", nil), "row", excel.SetHeaderCellStyle(excel.CellStyle{Bold: true})),
+	}
+	for i, header := range headers {
+		headerCells = append(headerCells, excel.NewHeaderCell(header, fmt.Sprintf("column_%d", i), excel.SetHeaderCellStyle(excel.CellStyle{Bold: true})))
+	}
+	headerCells = append(headerCells, excel.NewHeaderCell(m.localizer.Localize(lang, "ImportErrorMessage", nil), "error", excel.SetHeaderCellWidth(60), excel.SetHeaderCellStyle(excel.CellStyle{Bold: true})))
+	table.SetHeaderColumn(headerCells)
+
+	for _, row := range rows {
+		data := map[string]excel.BodyCell{
+			"row":   excel.NewBodyCell(row.Number, "int"),
+			"error": excel.NewBodyCell(messages[row.Number], "string"),
+		}
+		for i := range headers {
+			value := ""
+			if i < len(row.Cells) {
+				value = row.Cells[i]
+			}
+			data[fmt.Sprintf("column_%d", i)] = excel.NewBodyCell(value, "string")
+		}
+		table.AddBodyRow(data)
+	}
+
+	sheet := excel.NewSheet("Errors")
+	sheet.Data = append(sheet.Data, table)
+
+	var buf bytes.Buffer
+	if err := m.excel.ExportToWriter(&buf, []excel.Sheet{sheet}); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func buildImportMapping(headerMapping []productdto.ImportProductHeaderMappingInput) excel.HeaderMapping {
+	mapping := make(excel.HeaderMapping, len(headerMapping))
+	for _, m := range headerMapping {
+		key := excel.HeaderKey(m.Field)
+		if m.Field == productdto.ImportProductFieldAttribute {
+			key = importAttributeKey(m)
+		}
+		mapping[excel.NormalizeHeader(m.Header)] = key
+	}
+	return mapping
+}
+
+func importAttributeKey(mapping productdto.ImportProductHeaderMappingInput) excel.HeaderKey {
+	return excel.HeaderKey(importAttributeKeyPfx + mapping.AttributeID.String())
+}
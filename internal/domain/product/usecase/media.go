@@ -0,0 +1,290 @@
+package productusecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	producteventpublisher "gobase/internal/domain/product/event/publisher"
+	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/blobsvc"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// mediaExtensions maps the content types accepted as product media to the extension of their storage key.
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"video/mp4":  ".mp4",
+}
+
+// UploadMedia stores a file in the blob storage and adds it to the media of a product. The content type is
+// sniffed from the file rather than trusted from the client.
+func (m *UseCaseModule) UploadMedia(ctx context.Context, input productdto.UploadProductMediaInput) (*productdto.ProductMedia, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/UploadMedia", map[string]any{
+		"filename": input.File.Filename,
+		"size":     input.File.Size,
+	})
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.File.File == nil || input.File.Size <= 0 {
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorFieldRequired", map[string]interface{}{
+			"FieldName": helper.MessageParam("File"),
+		})
+	}
+
+	maxSizeMB := m.cfg.Product.MediaMaxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = DefaultMediaMaxSizeMB
+	}
+	if input.File.Size > int64(maxSizeMB)*1024*1024 {
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorMediaTooLarge", map[string]interface{}{
+			"MaxSizeMB": maxSizeMB,
+		})
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(input.File.File, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	extension, ok := mediaExtensions[contentType]
+	if !ok {
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorUnsupportedMediaType", nil)
+	}
+
+	_, err = m.repository.Product().FindByID(ctx, input.ProductID.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "Product")
+		}
+		return nil, err
+	}
+
+	mediaEntity := &masterdataentity.ProductMedia{
+		Id:          uuid.New(),
+		ProductId:   input.ProductID,
+		FileName:    input.File.Filename,
+		ContentType: contentType,
+		Size:        input.File.Size,
+		AltText:     input.AltText,
+		CreatedAt:   time.Now(),
+	}
+	mediaEntity.StorageKey = fmt.Sprintf("products/%s/%s%s", mediaEntity.ProductId, mediaEntity.Id, extension)
+
+	if input.VariantID != nil {
+		err = m.checkMediaVariant(ctx, input.ProductID, *input.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		mediaEntity.VariantId = *input.VariantID
+	}
+
+	if input.Position != nil {
+		mediaEntity.Position = *input.Position
+	} else {
+		mediaEntity.Position, err = m.repository.NextMediaPosition(ctx, input.ProductID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = m.storage.Put(ctx, mediaEntity.StorageKey, io.MultiReader(bytes.NewReader(head), input.File.File), input.File.Size, contentType)
+	if errors.Is(err, blobsvc.ErrStorageDisabled) {
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorMediaStorageDisabled", nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := m.repository.WithTx(ctx, tx).Media().Create(ctx, mediaEntity)
+		if err != nil {
+			return err
+		}
+
+		return m.publishMediaChanged(ctx, tx, mediaEntity.ProductId)
+	})
+	if err != nil {
+		if deleteErr := m.storage.Delete(ctx, mediaEntity.StorageKey); deleteErr != nil {
+			log.Warn().Err(deleteErr).Str("key", mediaEntity.StorageKey).Msg("failed to delete the blob of an unsaved product media")
+		}
+		return nil, err
+	}
+
+	return productmapper.ProductMediaEntityToDTO(mediaEntity), nil
+}
+
+// UpdateMedia changes the alt text, position or variant of a media.
+func (m *UseCaseModule) UpdateMedia(ctx context.Context, input productdto.UpdateProductMediaInput) (*productdto.ProductMedia, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/UpdateMedia")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaEntity, err := m.repository.Media().FindByID(ctx, input.ID.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductMedia")
+		}
+		return nil, err
+	}
+
+	if input.VariantID != nil {
+		err = m.checkMediaVariant(ctx, mediaEntity.ProductId, *input.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		mediaEntity.VariantId = *input.VariantID
+	}
+	if input.AltText != nil {
+		mediaEntity.AltText = *input.AltText
+	}
+	if input.Position != nil {
+		mediaEntity.Position = *input.Position
+	}
+	mediaEntity.UpdatedAt = time.Now()
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := m.repository.WithTx(ctx, tx).Media().Update(ctx, mediaEntity)
+		if err != nil {
+			return err
+		}
+
+		return m.publishMediaChanged(ctx, tx, mediaEntity.ProductId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return productmapper.ProductMediaEntityToDTO(mediaEntity), nil
+}
+
+// DeleteMedia removes a media with its file. The file goes first, so a failed delete can simply be retried.
+func (m *UseCaseModule) DeleteMedia(ctx context.Context, id uuid.UUID) (*productdto.ProductMedia, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/DeleteMedia")
+	defer span.End()
+
+	mediaEntity, err := m.repository.Media().FindByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductMedia")
+		}
+		return nil, err
+	}
+
+	err = m.storage.Delete(ctx, mediaEntity.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := m.repository.WithTx(ctx, tx).DeleteMediaIn(ctx, []uuid.UUID{mediaEntity.Id})
+		if err != nil {
+			return err
+		}
+
+		return m.publishMediaChanged(ctx, tx, mediaEntity.ProductId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return productmapper.ProductMediaEntityToDTO(mediaEntity), nil
+}
+
+// MediaURL returns a signed URL to download the file of a media.
+func (m *UseCaseModule) MediaURL(ctx context.Context, media *productdto.ProductMedia) (string, error) {
+	ttlSecond := m.cfg.Product.MediaURLTTLSecond
+	if ttlSecond <= 0 {
+		ttlSecond = defaultMediaURLTTLSecond
+	}
+
+	return m.storage.SignedURL(ctx, media.StorageKey, time.Duration(ttlSecond)*time.Second)
+}
+
+// CleanupOrphanMedia deletes a batch of the media left behind by deleted products, with their files, and returns
+// how many were deleted. Media whose file can not be deleted are kept for the next run.
+func (m *UseCaseModule) CleanupOrphanMedia(ctx context.Context) (int, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/CleanupOrphanMedia")
+	defer span.End()
+
+	batchSize := m.cfg.Product.MediaCleanupBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultMediaCleanupBatchSize
+	}
+
+	mediaEntities, err := m.repository.FindOrphanMedia(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var deletedIds []uuid.UUID
+	for _, mediaEntity := range mediaEntities {
+		err = m.storage.Delete(ctx, mediaEntity.StorageKey)
+		if err != nil {
+			log.Warn().Err(err).Str("key", mediaEntity.StorageKey).Msg("failed to delete the blob of an orphan product media")
+			continue
+		}
+		deletedIds = append(deletedIds, mediaEntity.Id)
+	}
+
+	err = m.repository.DeleteMediaIn(ctx, deletedIds)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(deletedIds), nil
+}
+
+// publishMediaChanged publishes a product.updated event for a change of the media of a product.
+func (m *UseCaseModule) publishMediaChanged(ctx context.Context, tx bun.Tx, productId uuid.UUID) error {
+	return m.productEventPublisher.PublishProductUpdated(ctx, tx, &producteventpublisher.ProductUpdated{
+		ProductIds: []uuid.UUID{productId},
+		Change:     producteventpublisher.ProductMediaChanged,
+	})
+}
+
+// checkMediaVariant checks that a media is linked to a variant of its own product.
+func (m *UseCaseModule) checkMediaVariant(ctx context.Context, productId uuid.UUID, variantId uuid.UUID) error {
+	variantEntity, err := m.repository.Variant().FindByID(ctx, variantId.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductVariant")
+		}
+		return err
+	}
+	if variantEntity.ProductId != productId {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorMediaVariantNotOfProduct", nil)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package productusecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	producteventpublisher "gobase/internal/domain/product/event/publisher"
+	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// SchedulePrice adds a price window to the price history of a variant. The price is in the currency of the variant.
+func (m *UseCaseModule) SchedulePrice(ctx context.Context, input productdto.ScheduleProductVariantPriceInput) (*productdto.ProductVariantPriceHistory, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/SchedulePrice")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	variantEntity, err := m.repository.Variant().FindByID(ctx, input.VariantID.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductVariant")
+		}
+		return nil, err
+	}
+
+	createdHistory, err := m.repository.VariantPriceHistory().Create(ctx, input.ToEntity(variantEntity.Currency))
+	if err != nil {
+		return nil, err
+	}
+
+	return productmapper.ProductVariantPriceHistoryEntityToDTO(createdHistory), nil
+}
+
+// PublishPriceWindowChanges publishes a variant.price_changed event for every price window that opened or closed
+// since the last run, and returns the number of events published.
+func (m *UseCaseModule) PublishPriceWindowChanges(ctx context.Context) (int, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/PublishPriceWindowChanges")
+	defer span.End()
+
+	batchSize := m.cfg.Product.PriceScheduleBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPriceScheduleBatchSize
+	}
+
+	now := time.Now()
+	published := 0
+
+	err := m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		opened, err := txRepo.OpenDuePriceWindows(ctx, now, batchSize)
+		if err != nil {
+			return err
+		}
+		if err := m.publishPriceChanges(ctx, tx, producteventpublisher.VariantPriceWindowOpened, opened); err != nil {
+			return err
+		}
+
+		// Windows opened above that already ended are closed in the same run.
+		closed, err := txRepo.CloseDuePriceWindows(ctx, now, batchSize)
+		if err != nil {
+			return err
+		}
+		if err := m.publishPriceChanges(ctx, tx, producteventpublisher.VariantPriceWindowClosed, closed); err != nil {
+			return err
+		}
+
+		published = len(opened) + len(closed)
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+func (m *UseCaseModule) publishPriceChanges(ctx context.Context, tx bun.Tx, reason string, histories []*masterdataentity.ProductVariantPriceHistory) error {
+	for _, history := range histories {
+		err := m.productEventPublisher.PublishVariantPriceChanged(ctx, tx, &producteventpublisher.VariantPriceChanged{
+			VariantId:    history.VariantId,
+			Reason:       reason,
+			PriceHistory: history,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
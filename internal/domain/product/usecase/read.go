@@ -2,11 +2,17 @@ package productusecase
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 
+	masterdataentity "gobase/internal/db/masterdata/entity"
 	productdto "gobase/internal/domain/product/dto"
 	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/service/authsvc"
 	"gobase/internal/pkg/service/crud"
 	"gobase/internal/pkg/service/otelsvc"
 )
@@ -19,23 +25,41 @@ func (m *UseCaseModule) FindById(ctx context.Context, id uuid.UUID) (*productdto
 	if err != nil {
 		return nil, err
 	}
+	// Products that are not published are hidden from the callers that can not list them.
+	if productdto.ProductStatus(productEntity.Status) != productdto.ProductStatusPublished && !canReadUnpublished(ctx) {
+		return nil, crud.ErrNotFound
+	}
+
+	product := productmapper.ProductEntityToDTO(productEntity)
+
+	err = m.translateProducts(ctx, []*productdto.Product{product})
+	if err != nil {
+		return nil, err
+	}
 
-	return productmapper.ProductEntityToDTO(productEntity), nil
+	return product, nil
 }
 
 func (m *UseCaseModule) FindAll(ctx context.Context, qop *productdto.ProductQop) (*crud.PageResult[*productdto.Product], error) {
 	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/FindAll")
 	defer span.End()
 
-	var options *crud.QueryOptions
+	lang := language.FromContext(ctx)
+	options := m.queryOptions(ctx, qop, lang, uuid.Nil)
 
-	if qop != nil {
-		qop.WithAllowedSorts([]string{"id", "name", "created_at", "updated_at"})
-		options = qop.ToQueryOptions()
+	var search string
+	if qop != nil && qop.Search != nil {
+		search = strings.TrimSpace(*qop.Search)
 	}
 
 	// Get product entities from repository
-	entityResult, err := m.repository.Product().FindAll(ctx, options)
+	var entityResult *crud.PageResult[masterdataentity.Product]
+	var err error
+	if search != "" {
+		entityResult, err = m.repository.Search(ctx, options, search, lang)
+	} else {
+		entityResult, err = m.repository.Product().FindAll(ctx, options)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +68,13 @@ func (m *UseCaseModule) FindAll(ctx context.Context, qop *productdto.ProductQop)
 	productDTOs := make([]*productdto.Product, len(entityResult.Items))
 	for i, p := range entityResult.Items {
 		productDTOs[i] = productmapper.ProductEntityToDTO(&p)
+		productDTOs[i].Search = search
+		productDTOs[i].SearchLanguage = lang
+	}
+
+	err = m.translateProducts(ctx, productDTOs)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create the final DTO page result
@@ -52,3 +83,107 @@ func (m *UseCaseModule) FindAll(ctx context.Context, qop *productdto.ProductQop)
 		Pagination: entityResult.Pagination,
 	}, nil
 }
+
+// FindConnection finds the products matching the qop as a Relay connection paginated by args. The pagination of the
+// qop is replaced by the window of args, and the products are ordered by id after the sorts of the qop, so the
+// cursors point at the same products between requests as long as none are added or removed before them.
+func (m *UseCaseModule) FindConnection(ctx context.Context, qop *productdto.ProductQop, args crud.ConnectionArgs) (*productdto.ProductConnection, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/FindConnection")
+	defer span.End()
+
+	window, err := args.Window()
+	if err != nil {
+		if errors.Is(err, crud.ErrInvalidCursor) {
+			return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorInvalidCursor", nil)
+		}
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorInvalidConnectionArgs", nil)
+	}
+
+	if qop == nil {
+		qop = &productdto.ProductQop{}
+	}
+	qop.Pagination = window.Pagination()
+	// A search already orders the products by rank and id.
+	if qop.Search == nil || strings.TrimSpace(*qop.Search) == "" {
+		qop.Sorts = append(qop.Sorts, crud.Sort{Field: "id", Direction: "ASC"})
+	}
+
+	page, err := m.FindAll(ctx, qop)
+	if err != nil {
+		return nil, err
+	}
+
+	return crud.NewConnection(page.Items, window), nil
+}
+
+// queryOptions converts the qop to query options, resolving the filters that need a subquery.
+// The attribute value filter of excludeAttributeId is left out, so it can be counted on its own.
+func (m *UseCaseModule) queryOptions(ctx context.Context, qop *productdto.ProductQop, lang string, excludeAttributeId uuid.UUID) *crud.QueryOptions {
+	options := crud.NewQueryOptions()
+
+	if qop != nil {
+		qop.WithAllowedSorts([]string{"id", "name", "created_at", "updated_at"})
+
+		// The name is searched in the requested language, so it can not be a plain column filter.
+		name := qop.Filters.Name
+		if lang != language.Default {
+			qop.Filters.Name = nil
+		}
+		options = qop.ToQueryOptions()
+		qop.Filters.Name = name
+
+		if name != nil && lang != language.Default {
+			options.AndFilter(crud.Filter{
+				Field:    "id",
+				Operator: crud.OperatorIn,
+				Value:    m.repository.ProductIdsByTranslatedNameQuery(lang, *name),
+			})
+		}
+
+		if qop.Filters.CategoryID != nil {
+			options.AndFilter(crud.Filter{
+				Field:    "id",
+				Operator: crud.OperatorIn,
+				Value:    m.categoryRepository.ProductIdsInTreeQuery(*qop.Filters.CategoryID),
+			})
+		}
+
+		for _, attributeValue := range qop.Filters.AttributeValues {
+			if attributeValue == nil || attributeValue.AttributeID == excludeAttributeId || len(attributeValue.Values) == 0 {
+				continue
+			}
+			options.AndFilter(crud.Filter{
+				Field:    "id",
+				Operator: crud.OperatorIn,
+				Value:    m.repository.ProductIdsWithAttributeValuesQuery(attributeValue.AttributeID, attributeValue.Values),
+			})
+		}
+
+		if qop.Filters.Tags != nil {
+			if anyOf := m.tagFilterGroup(crud.LogicalOr, qop.Filters.Tags.AnyOf); anyOf != nil {
+				options.AndFilter(*anyOf)
+			}
+			if allOf := m.tagFilterGroup(crud.LogicalAnd, qop.Filters.Tags.AllOf); allOf != nil {
+				options.AndFilter(*allOf)
+			}
+		}
+	}
+
+	// Only published products are listed unless an internal caller asks for another status.
+	if qop == nil || qop.Filters.Status == nil || !canReadUnpublished(ctx) {
+		options.AndFilter(crud.Filter{
+			Field:    "status",
+			Operator: crud.OperatorEqual,
+			Value:    productdto.ProductStatusPublished,
+		})
+	}
+
+	return options
+}
+
+// canReadUnpublished tells whether the caller can read the products that are not published, which only the internal
+// callers, e.g. the back office, can.
+func canReadUnpublished(ctx context.Context) bool {
+	principal, ok := authsvc.FromContext(ctx)
+	return ok && principal.HasRole(authsvc.RoleInternal)
+}
@@ -0,0 +1,97 @@
+package productusecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/structprocessor"
+)
+
+// registerRules registers the business rules of the product inputs that their tags can not express.
+// They run after the tag validation, so they can rely on the tags being satisfied.
+func (m *UseCaseModule) registerRules() {
+	m.sp.RegisterRules(productdto.CreateProductInput{},
+		structprocessor.RuleFor(m.validateVariantPrices),
+		structprocessor.RuleFor(m.validateVariantAttributes),
+	)
+	m.sp.RegisterRules(productdto.SetProductVariantPricesInput{},
+		structprocessor.RuleFor(func(ctx context.Context, languageId string, input *productdto.SetProductVariantPricesInput) error {
+			return m.validateDiscountedPrices(languageId, input.Prices)
+		}),
+	)
+}
+
+// validateVariantPrices checks that no discounted price is higher than its price, and that each currency is priced
+// once per variant, the currency of the variant itself included.
+func (m *UseCaseModule) validateVariantPrices(ctx context.Context, languageId string, input *productdto.CreateProductInput) error {
+	for _, variant := range input.Variants {
+		if isAbovePrice(variant.DiscountedPrice, variant.Price.Amount) {
+			return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorDiscountedPriceAbovePrice", nil)
+		}
+
+		err := m.validateDiscountedPrices(languageId, variant.Prices)
+		if err != nil {
+			return err
+		}
+
+		currencies := lo.Map(variant.Prices, func(price productdto.ProductVariantPriceInput, _ int) string {
+			return price.Price.Currency
+		})
+		err = m.validatePriceCurrencies(languageId, variant.Price.Currency, currencies)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDiscountedPrices checks that no discounted price of a price list is higher than its price.
+func (m *UseCaseModule) validateDiscountedPrices(languageId string, prices []productdto.ProductVariantPriceInput) error {
+	for _, price := range prices {
+		if isAbovePrice(price.DiscountedPrice, price.Price.Amount) {
+			return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorDiscountedPriceAbovePrice", nil)
+		}
+	}
+	return nil
+}
+
+// validateVariantAttributes checks that each variant has at most one value per attribute, and that the attributes exist.
+func (m *UseCaseModule) validateVariantAttributes(ctx context.Context, languageId string, input *productdto.CreateProductInput) error {
+	var attributeIds []uuid.UUID
+	for _, variant := range input.Variants {
+		variantAttributeIds := lo.Map(variant.Attributes, func(attribute productdto.CreateProductAttributeValueInput, _ int) uuid.UUID {
+			return attribute.ID
+		})
+		if len(lo.Uniq(variantAttributeIds)) != len(variantAttributeIds) {
+			return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorDuplicateVariantAttribute", nil)
+		}
+		attributeIds = append(attributeIds, variantAttributeIds...)
+	}
+
+	attributeIds = lo.Uniq(attributeIds)
+	if len(attributeIds) == 0 {
+		return nil
+	}
+
+	attributes, err := m.repository.Attribute().FindIn(ctx, "id", lo.ToAnySlice(attributeIds), &crud.QueryOptions{})
+	if err != nil {
+		return err
+	}
+	if len(attributes) != len(attributeIds) {
+		return helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductAttribute")
+	}
+
+	return nil
+}
+
+// isAbovePrice reports whether a discounted price is given and higher than the price.
+func isAbovePrice(discountedPrice *decimal.Decimal, price decimal.Decimal) bool {
+	return discountedPrice != nil && discountedPrice.GreaterThan(price)
+}
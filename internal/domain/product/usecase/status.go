@@ -0,0 +1,107 @@
+package productusecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	producteventpublisher "gobase/internal/domain/product/event/publisher"
+	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// productStatusTransition lists the statuses a transition can start from and the status it ends in.
+type productStatusTransition struct {
+	from []productdto.ProductStatus
+	to   productdto.ProductStatus
+}
+
+// productStatusTransitions is the publication lifecycle of a product. A transition that is not listed is not allowed.
+var productStatusTransitions = map[productdto.ProductStatusTransition]productStatusTransition{
+	productdto.ProductStatusTransitionSubmitForReview: {
+		from: []productdto.ProductStatus{productdto.ProductStatusDraft},
+		to:   productdto.ProductStatusInReview,
+	},
+	productdto.ProductStatusTransitionReject: {
+		from: []productdto.ProductStatus{productdto.ProductStatusInReview},
+		to:   productdto.ProductStatusDraft,
+	},
+	productdto.ProductStatusTransitionPublish: {
+		from: []productdto.ProductStatus{productdto.ProductStatusInReview},
+		to:   productdto.ProductStatusPublished,
+	},
+	productdto.ProductStatusTransitionUnpublish: {
+		from: []productdto.ProductStatus{productdto.ProductStatusPublished},
+		to:   productdto.ProductStatusDraft,
+	},
+	productdto.ProductStatusTransitionArchive: {
+		from: []productdto.ProductStatus{productdto.ProductStatusDraft, productdto.ProductStatusInReview, productdto.ProductStatusPublished},
+		to:   productdto.ProductStatusArchived,
+	},
+	productdto.ProductStatusTransitionRestore: {
+		from: []productdto.ProductStatus{productdto.ProductStatusArchived},
+		to:   productdto.ProductStatusDraft,
+	},
+}
+
+// TransitionStatus moves a product along its publication lifecycle and publishes a product.status_changed event.
+func (m *UseCaseModule) TransitionStatus(ctx context.Context, id uuid.UUID, transition productdto.ProductStatusTransition) (*productdto.Product, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/TransitionStatus", map[string]any{
+		"id":         id,
+		"transition": transition,
+	})
+	defer span.End()
+
+	productEntity, err := m.repository.Product().FindByID(ctx, id.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "Product")
+		}
+		return nil, err
+	}
+
+	from := productdto.ProductStatus(productEntity.Status)
+	rule, ok := productStatusTransitions[transition]
+	if !ok || !lo.Contains(rule.from, from) {
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorProductStatusTransitionNotAllowed", map[string]interface{}{
+			"Transition": transition,
+			"Status":     from,
+		})
+	}
+
+	var updatedProduct *masterdataentity.Product
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+
+		// The product is only moved when it is still in the status it was read in.
+		updatedProduct, err = m.repository.WithTx(ctx, tx).TransitionStatus(ctx, id, []string{string(from)}, string(rule.to))
+		if err != nil {
+			if errors.Is(err, crud.ErrNotFound) {
+				return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindConflict, "ErrorProductStatusChanged", nil)
+			}
+			return err
+		}
+
+		return m.productEventPublisher.PublishProductStatusChanged(ctx, tx, &producteventpublisher.ProductStatusChanged{
+			ProductId:  updatedProduct.Id,
+			Transition: string(transition),
+			From:       string(from),
+			To:         updatedProduct.Status,
+			Product:    updatedProduct,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return productmapper.ProductEntityToDTO(updatedProduct), nil
+}
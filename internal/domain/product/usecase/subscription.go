@@ -0,0 +1,82 @@
+package productusecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	productdto "gobase/internal/domain/product/dto"
+	"gobase/internal/pkg/service/broadcastsvc"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// BroadcastProductEvent tells the GraphQL subscriptions of every instance listening for the event about a change of
+// a product. Each subscription loads the product itself, so nothing is loaded where no subscription is open.
+func (m *UseCaseModule) BroadcastProductEvent(ctx context.Context, eventType productdto.ProductEventType, productId uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/BroadcastProductEvent", map[string]any{
+		"type":      string(eventType),
+		"productId": productId.String(),
+	})
+	defer span.End()
+
+	return m.productEventRelay.Publish(ctx, &productdto.ProductEvent{
+		Type:      eventType,
+		ProductID: productId,
+	})
+}
+
+// BroadcastVariantEvent broadcasts an update of the product of a variant.
+func (m *UseCaseModule) BroadcastVariantEvent(ctx context.Context, variantId uuid.UUID) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/BroadcastVariantEvent", map[string]any{
+		"variantId": variantId.String(),
+	})
+	defer span.End()
+
+	variant, err := m.repository.Variant().FindByID(ctx, variantId.String())
+	if errors.Is(err, crud.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return m.BroadcastProductEvent(ctx, productdto.ProductEventUpdated, variant.ProductId)
+}
+
+// SubscribeProductEvents returns the products of the events of a type, limited to one product when productId is
+// given. The products are loaded with the context of the subscription, so in the language of its connection and
+// only when its caller can read them. The channel is closed once the context is done.
+func (m *UseCaseModule) SubscribeProductEvents(ctx context.Context, eventType productdto.ProductEventType, productId *uuid.UUID) <-chan *productdto.Product {
+	productIds := broadcastsvc.Subscribe(ctx, m.productEvents, func(event *productdto.ProductEvent) (uuid.UUID, bool) {
+		if event.Type != eventType || (productId != nil && event.ProductID != *productId) {
+			return uuid.Nil, false
+		}
+		return event.ProductID, true
+	})
+
+	products := make(chan *productdto.Product)
+	go func() {
+		defer close(products)
+
+		for id := range productIds {
+			product, err := m.FindById(ctx, id)
+			if err != nil {
+				if !errors.Is(err, crud.ErrNotFound) && ctx.Err() == nil {
+					log.Error().Err(err).Str("productId", id.String()).Msg("failed to load the product of a subscription event")
+				}
+				continue
+			}
+
+			select {
+			case products <- product:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return products
+}
@@ -0,0 +1,261 @@
+package productusecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	producteventpublisher "gobase/internal/domain/product/event/publisher"
+	productmapper "gobase/internal/domain/product/mapper"
+	productrepository "gobase/internal/domain/product/repository"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// DefaultTagSuggestionLimit and MaxTagSuggestionLimit bound the number of suggested tags.
+const (
+	DefaultTagSuggestionLimit = 10
+	MaxTagSuggestionLimit     = 50
+)
+
+// AddTags adds tags to many products at once, creating the tags that do not exist yet.
+// It returns the added tags with their refreshed usage counts.
+func (m *UseCaseModule) AddTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/AddTags", map[string]any{
+		"productIds": input.ProductIDs,
+		"tags":       input.Tags,
+	})
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	tagEntities, err := m.normaliseTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagIds []uuid.UUID
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		productIds, err := m.checkTaggedProducts(ctx, txRepo, input.ProductIDs)
+		if err != nil {
+			return err
+		}
+
+		tagEntities, err = txRepo.FindOrCreateTags(ctx, tagEntities)
+		if err != nil {
+			return err
+		}
+		tagIds = lo.Map(tagEntities, func(tag *masterdataentity.ProductTag, _ int) uuid.UUID {
+			return tag.Id
+		})
+
+		err = txRepo.AddProductTags(ctx, productIds, tagIds)
+		if err != nil {
+			return err
+		}
+
+		err = txRepo.RefreshTagUsageCounts(ctx, tagIds)
+		if err != nil {
+			return err
+		}
+
+		return m.productEventPublisher.PublishProductUpdated(ctx, tx, &producteventpublisher.ProductUpdated{
+			ProductIds: productIds,
+			Change:     producteventpublisher.ProductTagsChanged,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.findTagsIn(ctx, tagIds)
+}
+
+// RemoveTags removes tags from many products at once. Tags that do not exist are ignored.
+// It returns the removed tags with their refreshed usage counts.
+func (m *UseCaseModule) RemoveTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/RemoveTags", map[string]any{
+		"productIds": input.ProductIDs,
+		"tags":       input.Tags,
+	})
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	tagEntities, err := m.normaliseTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+	slugs := lo.Map(tagEntities, func(tag *masterdataentity.ProductTag, _ int) any {
+		return tag.Slug
+	})
+
+	var tagIds []uuid.UUID
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		productIds, err := m.checkTaggedProducts(ctx, txRepo, input.ProductIDs)
+		if err != nil {
+			return err
+		}
+
+		existingTags, err := txRepo.Tag().FindIn(ctx, "slug", slugs, &crud.QueryOptions{})
+		if err != nil {
+			return err
+		}
+		tagIds = lo.Map(existingTags, func(tag *masterdataentity.ProductTag, _ int) uuid.UUID {
+			return tag.Id
+		})
+
+		err = txRepo.RemoveProductTags(ctx, productIds, tagIds)
+		if err != nil {
+			return err
+		}
+
+		err = txRepo.RefreshTagUsageCounts(ctx, tagIds)
+		if err != nil {
+			return err
+		}
+
+		return m.productEventPublisher.PublishProductUpdated(ctx, tx, &producteventpublisher.ProductUpdated{
+			ProductIds: productIds,
+			Change:     producteventpublisher.ProductTagsChanged,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.findTagsIn(ctx, tagIds)
+}
+
+// SuggestTags returns the tags starting with the given text for autocompletion, most used first.
+func (m *UseCaseModule) SuggestTags(ctx context.Context, prefix string, limit *int) ([]*productdto.ProductTag, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "ProductUseCase/SuggestTags", map[string]any{
+		"prefix": prefix,
+	})
+	defer span.End()
+
+	size := DefaultTagSuggestionLimit
+	if limit != nil && *limit > 0 {
+		size = min(*limit, MaxTagSuggestionLimit)
+	}
+
+	// The prefix is normalised like the tags, so that "Summer Sale" suggests "summer-sale".
+	tagEntities, err := m.repository.FindTagsByPrefix(ctx, helper.Slugify(prefix), size)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(tagEntities, func(tag *masterdataentity.ProductTag, _ int) *productdto.ProductTag {
+		return productmapper.ProductTagEntityToDTO(tag)
+	}), nil
+}
+
+// normaliseTags turns the given texts into tags with a unique slug each. The first text of a slug is kept as its name.
+func (m *UseCaseModule) normaliseTags(texts []string) ([]*masterdataentity.ProductTag, error) {
+	tags := make([]*masterdataentity.ProductTag, 0, len(texts))
+	seen := make(map[string]bool, len(texts))
+
+	for _, text := range texts {
+		slug := helper.Slugify(text)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		tags = append(tags, &masterdataentity.ProductTag{
+			Id:   uuid.New(),
+			Slug: slug,
+			Name: strings.TrimSpace(text),
+		})
+	}
+
+	if len(tags) == 0 {
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorFieldRequired", map[string]interface{}{
+			"FieldName": helper.MessageParam("Tags"),
+		})
+	}
+
+	return tags, nil
+}
+
+// checkTaggedProducts checks that all the given products exist and returns their ids without duplicates.
+func (m *UseCaseModule) checkTaggedProducts(ctx context.Context, repo productrepository.Repository, productIds []uuid.UUID) ([]uuid.UUID, error) {
+	productIds = lo.Uniq(productIds)
+
+	products, err := repo.Product().FindIn(ctx, "id", lo.ToAnySlice(productIds), &crud.QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) != len(productIds) {
+		return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "Product")
+	}
+
+	return productIds, nil
+}
+
+// findTagsIn returns the given tags ordered by their slug.
+func (m *UseCaseModule) findTagsIn(ctx context.Context, tagIds []uuid.UUID) ([]*productdto.ProductTag, error) {
+	tags := []*productdto.ProductTag{}
+	if len(tagIds) == 0 {
+		return tags, nil
+	}
+
+	tagEntities, err := m.repository.Tag().FindIn(ctx, "id", lo.ToAnySlice(tagIds), &crud.QueryOptions{
+		Sorts: []crud.Sort{{Field: "slug", Direction: "asc"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tagEntities {
+		tags = append(tags, productmapper.ProductTagEntityToDTO(tag))
+	}
+
+	return tags, nil
+}
+
+// tagFilterGroup matches the products having the tags with the given texts, combined with the given operator.
+// It returns nil when none of the texts makes a slug.
+func (m *UseCaseModule) tagFilterGroup(operator crud.LogicalOperator, texts []string) *crud.FilterGroup {
+	slugs := lo.Uniq(lo.Compact(lo.Map(texts, func(text string, _ int) string {
+		return helper.Slugify(text)
+	})))
+	if len(slugs) == 0 {
+		return nil
+	}
+
+	return &crud.FilterGroup{
+		Operator: operator,
+		Filters: lo.Map(slugs, func(slug string, _ int) any {
+			return crud.Filter{
+				Field:    "id",
+				Operator: crud.OperatorIn,
+				Value:    m.repository.ProductIdsWithTagQuery(slug),
+			}
+		}),
+	}
+}
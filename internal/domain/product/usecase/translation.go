@@ -0,0 +1,108 @@
+package productusecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	masterdataentity "gobase/internal/db/masterdata/entity"
+	productdto "gobase/internal/domain/product/dto"
+	producteventpublisher "gobase/internal/domain/product/event/publisher"
+	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/service/crud"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// SetTranslations replaces the translations of a product. The name and description of the product
+// itself are the content in the default language, so they can not be translated to it.
+func (m *UseCaseModule) SetTranslations(ctx context.Context, input productdto.SetProductTranslationsInput) ([]*productdto.ProductTranslation, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/SetTranslations")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := lo.Map(input.Translations, func(translation productdto.ProductTranslationInput, _ int) string {
+		return translation.Language
+	})
+	if len(lo.Uniq(languages)) != len(languages) || lo.Contains(languages, language.Default) {
+		return nil, helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorDuplicateTranslation", map[string]interface{}{
+			"DefaultLanguage": language.Default,
+		})
+	}
+
+	productEntity, err := m.repository.Product().FindByID(ctx, input.ProductID.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "Product")
+		}
+		return nil, err
+	}
+
+	translationEntities := lo.Map(input.Translations, func(translation productdto.ProductTranslationInput, _ int) *masterdataentity.ProductTranslation {
+		return translation.ToEntity(productEntity.Id)
+	})
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		txRepo := m.repository.WithTx(ctx, tx)
+
+		err := txRepo.ReplaceTranslations(ctx, productEntity.Id, translationEntities)
+		if err != nil {
+			return err
+		}
+
+		err = txRepo.RefreshSearchDocuments(ctx, []uuid.UUID{productEntity.Id})
+		if err != nil {
+			return err
+		}
+
+		return m.productEventPublisher.PublishProductUpdated(ctx, tx, &producteventpublisher.ProductUpdated{
+			ProductIds: []uuid.UUID{productEntity.Id},
+			Change:     producteventpublisher.ProductTranslationsChanged,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(translationEntities, func(translation *masterdataentity.ProductTranslation, _ int) *productdto.ProductTranslation {
+		return productmapper.ProductTranslationEntityToDTO(translation)
+	}), nil
+}
+
+// translateProducts replaces the content of the products with their translation in the requested language.
+func (m *UseCaseModule) translateProducts(ctx context.Context, products []*productdto.Product) error {
+	lang := language.FromContext(ctx)
+	if lang == language.Default || len(products) == 0 {
+		return nil
+	}
+
+	productIds := lo.Map(products, func(product *productdto.Product, _ int) uuid.UUID {
+		return product.ID
+	})
+
+	translations, err := m.repository.FindTranslationsIn(ctx, productIds, lang)
+	if err != nil {
+		return err
+	}
+
+	translationsByProduct := lo.KeyBy(translations, func(translation *masterdataentity.ProductTranslation) uuid.UUID {
+		return translation.ProductId
+	})
+
+	for _, product := range products {
+		productmapper.ApplyProductTranslation(product, translationsByProduct[product.ID])
+	}
+
+	return nil
+}
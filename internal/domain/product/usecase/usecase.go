@@ -3,42 +3,111 @@ package productusecase
 import (
 	"context"
 
+	"clodeo.tech/public/go-universe/pkg/localization"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 
+	"gobase/config"
+	categoryrepository "gobase/internal/domain/category/repository"
 	productdto "gobase/internal/domain/product/dto"
 	producteventpublisher "gobase/internal/domain/product/event/publisher"
 	productrepository "gobase/internal/domain/product/repository"
+	"gobase/internal/pkg/helper/excel"
+	"gobase/internal/pkg/service/blobsvc"
+	"gobase/internal/pkg/service/broadcastsvc"
 	"gobase/internal/pkg/service/crud"
 	structprocessor "gobase/internal/pkg/service/structprocessor"
 )
 
+const (
+	// languageId is the language of the error messages returned by the product use case.
+	languageId = "id"
+
+	defaultPriceScheduleBatchSize = 100
+	defaultMediaURLTTLSecond      = 900
+	defaultMediaCleanupBatchSize  = 100
+)
+
+// DefaultMediaMaxSizeMB is the size limit of the uploaded media when the product config sets none.
+const DefaultMediaMaxSizeMB = 10
+
 type UseCase interface {
 	Create(ctx context.Context, productInput productdto.CreateProductInput) (*productdto.Product, error)
 	FindById(ctx context.Context, id uuid.UUID) (*productdto.Product, error)
+	FindByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.Product, error)
+	FindVariantsByIds(ctx context.Context, ids []uuid.UUID) ([]*productdto.ProductVariant, error)
 	CreateAttribute(ctx context.Context, input productdto.CreateProductAttributeInput) (*productdto.ProductAttribute, error)
 	FindAll(ctx context.Context, qop *productdto.ProductQop) (*crud.PageResult[*productdto.Product], error)
+	FindConnection(ctx context.Context, qop *productdto.ProductQop, args crud.ConnectionArgs) (*productdto.ProductConnection, error)
+	ImportProducts(ctx context.Context, input productdto.ImportProductsInput) (*productdto.ImportProductsResult, error)
+	SetVariantPrices(ctx context.Context, input productdto.SetProductVariantPricesInput) ([]*productdto.ProductVariantPrice, error)
+	SchedulePrice(ctx context.Context, input productdto.ScheduleProductVariantPriceInput) (*productdto.ProductVariantPriceHistory, error)
+	PublishPriceWindowChanges(ctx context.Context) (int, error)
+	TransitionStatus(ctx context.Context, id uuid.UUID, transition productdto.ProductStatusTransition) (*productdto.Product, error)
+	SetTranslations(ctx context.Context, input productdto.SetProductTranslationsInput) ([]*productdto.ProductTranslation, error)
+	FindFacets(ctx context.Context, qop *productdto.ProductQop) ([]*productdto.ProductFacet, error)
+	Clone(ctx context.Context, input productdto.CloneProductInput) (*productdto.Product, error)
+	FindBundleById(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error)
+	FindAllBundles(ctx context.Context, qop *productdto.ProductBundleQop) (*productdto.ProductBundleList, error)
+	CreateBundle(ctx context.Context, input productdto.CreateProductBundleInput) (*productdto.ProductBundle, error)
+	UpdateBundle(ctx context.Context, input productdto.UpdateProductBundleInput) (*productdto.ProductBundle, error)
+	DeleteBundle(ctx context.Context, id uuid.UUID) (*productdto.ProductBundle, error)
+	UploadMedia(ctx context.Context, input productdto.UploadProductMediaInput) (*productdto.ProductMedia, error)
+	UpdateMedia(ctx context.Context, input productdto.UpdateProductMediaInput) (*productdto.ProductMedia, error)
+	DeleteMedia(ctx context.Context, id uuid.UUID) (*productdto.ProductMedia, error)
+	MediaURL(ctx context.Context, media *productdto.ProductMedia) (string, error)
+	CleanupOrphanMedia(ctx context.Context) (int, error)
+	AddTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error)
+	RemoveTags(ctx context.Context, input productdto.ProductTagsInput) ([]*productdto.ProductTag, error)
+	SuggestTags(ctx context.Context, prefix string, limit *int) ([]*productdto.ProductTag, error)
+	BroadcastProductEvent(ctx context.Context, eventType productdto.ProductEventType, productId uuid.UUID) error
+	BroadcastVariantEvent(ctx context.Context, variantId uuid.UUID) error
+	SubscribeProductEvents(ctx context.Context, eventType productdto.ProductEventType, productId *uuid.UUID) <-chan *productdto.Product
 }
 
 type UseCaseModule struct {
+	cfg                   *config.MainConfig
 	bun                   *bun.DB
 	repository            productrepository.Repository
+	categoryRepository    categoryrepository.Repository
 	sp                    structprocessor.StructProcessorService
+	localizer             localization.Localizer
+	excel                 excel.Excel
 	productEventPublisher producteventpublisher.Event
+	storage               blobsvc.Storage
+	productEvents         *broadcastsvc.Broker[*productdto.ProductEvent]
+	productEventRelay     *broadcastsvc.Relay[*productdto.ProductEvent]
 }
 
 type UseCaseOpts struct {
+	Config                *config.MainConfig
 	Bun                   *bun.DB
 	Repository            productrepository.Repository
+	CategoryRepository    categoryrepository.Repository
 	SP                    structprocessor.StructProcessorService
+	Localizer             localization.Localizer
+	Excel                 excel.Excel
 	ProductEventPublisher producteventpublisher.Event
+	Storage               blobsvc.Storage
+	ProductEvents         *broadcastsvc.Broker[*productdto.ProductEvent]
+	ProductEventRelay     *broadcastsvc.Relay[*productdto.ProductEvent]
 }
 
 func NewUseCase(opts UseCaseOpts) UseCase {
-	return &UseCaseModule{
+	m := &UseCaseModule{
+		cfg:                   opts.Config,
 		bun:                   opts.Bun,
 		repository:            opts.Repository,
+		categoryRepository:    opts.CategoryRepository,
 		sp:                    opts.SP,
+		localizer:             opts.Localizer,
+		excel:                 opts.Excel,
 		productEventPublisher: opts.ProductEventPublisher,
+		storage:               opts.Storage,
+		productEvents:         opts.ProductEvents,
+		productEventRelay:     opts.ProductEventRelay,
 	}
+	m.registerRules()
+
+	return m
 }
@@ -2,13 +2,19 @@ package productusecase
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/samber/lo"
 	"github.com/uptrace/bun"
 
 	masterdataentity "gobase/internal/db/masterdata/entity"
 	productdto "gobase/internal/domain/product/dto"
+	producteventpublisher "gobase/internal/domain/product/event/publisher"
 	productmapper "gobase/internal/domain/product/mapper"
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/crud"
 	"gobase/internal/pkg/service/otelsvc"
 )
 
@@ -25,50 +31,16 @@ func (m *UseCaseModule) Create(ctx context.Context, productInput productdto.Crea
 
 	productEntity := productInput.ToEntity(true)
 
-	var createdProduct *masterdataentity.Product
-
 	// The transaction will handle the creation of the product and all its related entities.
 	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		txRepo := m.repository.WithTx(ctx, tx)
-
-		createdProduct, err = txRepo.Product().Create(ctx, productEntity)
-		if err != nil {
-			return err
-		}
-		err = m.productEventPublisher.PublishProductCreated(ctx, tx, createdProduct)
-		if err != nil {
-			return err
-		}
-
-		if len(productEntity.Variants) == 0 {
-			return nil
-		}
-
-		_, err = txRepo.Variant().CreateBulk(ctx, productEntity.Variants)
-		if err != nil {
-			return err
-		}
-
-		attributeValues := lo.FlatMap(productEntity.Variants, func(variant *masterdataentity.ProductVariant, _ int) []*masterdataentity.RelProductVariantProductAttribute {
-			return variant.Attributes
-		})
-
-		// Only create attribute values if there are any
-		if len(attributeValues) > 0 {
-			_, err = txRepo.VariantAttributeValue().CreateBulk(ctx, attributeValues)
-			if err != nil {
-				return err
-			}
-		}
-
-		return nil
+		return m.insertProducts(ctx, tx, []*masterdataentity.Product{productEntity})
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return productmapper.ProductEntityToDTO(createdProduct), nil
+	return productmapper.ProductEntityToDTO(productEntity), nil
 }
 
 func (m *UseCaseModule) CreateAttribute(ctx context.Context, input productdto.CreateProductAttributeInput) (*productdto.ProductAttribute, error) {
@@ -91,3 +63,140 @@ func (m *UseCaseModule) CreateAttribute(ctx context.Context, input productdto.Cr
 
 	return productmapper.ProductAttributeEntityToDTO(createdAttribute), nil
 }
+
+// SetVariantPrices replaces the price list of a variant in other currencies.
+func (m *UseCaseModule) SetVariantPrices(ctx context.Context, input productdto.SetProductVariantPricesInput) ([]*productdto.ProductVariantPrice, error) {
+	ctx, span := otelsvc.StartSpan(ctx, "ProductUseCase/SetVariantPrices")
+	defer span.End()
+
+	var err error
+
+	err = m.sp.TransformAndValidateByTag(ctx, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	variantEntity, err := m.repository.Variant().FindByID(ctx, input.VariantID.String())
+	if err != nil {
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, helper.GetErrorDataNotFoundWithParam(m.localizer, languageId, "ProductVariant")
+		}
+		return nil, err
+	}
+
+	priceEntities := lo.Map(input.Prices, func(price productdto.ProductVariantPriceInput, _ int) *masterdataentity.ProductVariantPrice {
+		return price.ToEntity(variantEntity.Id)
+	})
+
+	currencies := lo.Map(priceEntities, func(price *masterdataentity.ProductVariantPrice, _ int) string {
+		return price.Currency
+	})
+	err = m.validatePriceCurrencies(languageId, variantEntity.Currency, currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.bun.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := m.repository.WithTx(ctx, tx).ReplaceVariantPrices(ctx, variantEntity.Id, priceEntities)
+		if err != nil {
+			return err
+		}
+
+		return m.productEventPublisher.PublishProductUpdated(ctx, tx, &producteventpublisher.ProductUpdated{
+			ProductIds: []uuid.UUID{variantEntity.ProductId},
+			Change:     producteventpublisher.ProductPricesChanged,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(priceEntities, func(price *masterdataentity.ProductVariantPrice, _ int) *productdto.ProductVariantPrice {
+		return productmapper.ProductVariantPriceEntityToDTO(price)
+	}), nil
+}
+
+// validatePriceCurrencies checks that every currency of a price list is priced once, and that the base currency,
+// which is priced on the variant itself, is not in it.
+func (m *UseCaseModule) validatePriceCurrencies(languageId string, baseCurrency string, currencies []string) error {
+	currencies = lo.Map(currencies, func(currency string, _ int) string {
+		return strings.ToUpper(currency)
+	})
+	if len(lo.Uniq(currencies)) != len(currencies) {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorDuplicateCurrency", nil)
+	}
+	if lo.Contains(currencies, strings.ToUpper(baseCurrency)) {
+		return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorBaseCurrencyPriced", nil)
+	}
+	return nil
+}
+
+// insertProducts creates the products with their translations, variants, attribute values and prices, builds their
+// search documents, and publishes a product.created event for each of them. It must be called inside a transaction.
+func (m *UseCaseModule) insertProducts(ctx context.Context, tx bun.Tx, productEntities []*masterdataentity.Product) error {
+	txRepo := m.repository.WithTx(ctx, tx)
+
+	createdProducts, err := txRepo.Product().CreateBulk(ctx, productEntities)
+	if err != nil {
+		return err
+	}
+	for _, createdProduct := range createdProducts {
+		err = m.productEventPublisher.PublishProductCreated(ctx, tx, createdProduct)
+		if err != nil {
+			return err
+		}
+	}
+
+	translations := lo.FlatMap(productEntities, func(product *masterdataentity.Product, _ int) []*masterdataentity.ProductTranslation {
+		return product.Translations
+	})
+
+	if len(translations) > 0 {
+		_, err = txRepo.Translation().CreateBulk(ctx, translations)
+		if err != nil {
+			return err
+		}
+	}
+
+	productIds := lo.Map(productEntities, func(product *masterdataentity.Product, _ int) uuid.UUID {
+		return product.Id
+	})
+
+	variants := lo.FlatMap(productEntities, func(product *masterdataentity.Product, _ int) []*masterdataentity.ProductVariant {
+		return product.Variants
+	})
+	if len(variants) == 0 {
+		return txRepo.RefreshSearchDocuments(ctx, productIds)
+	}
+
+	_, err = txRepo.Variant().CreateBulk(ctx, variants)
+	if err != nil {
+		return err
+	}
+
+	attributeValues := lo.FlatMap(variants, func(variant *masterdataentity.ProductVariant, _ int) []*masterdataentity.RelProductVariantProductAttribute {
+		return variant.Attributes
+	})
+
+	// Only create attribute values if there are any
+	if len(attributeValues) > 0 {
+		_, err = txRepo.VariantAttributeValue().CreateBulk(ctx, attributeValues)
+		if err != nil {
+			return err
+		}
+	}
+
+	prices := lo.FlatMap(variants, func(variant *masterdataentity.ProductVariant, _ int) []*masterdataentity.ProductVariantPrice {
+		return variant.Prices
+	})
+
+	if len(prices) > 0 {
+		_, err = txRepo.VariantPrice().CreateBulk(ctx, prices)
+		if err != nil {
+			return err
+		}
+	}
+
+	return txRepo.RefreshSearchDocuments(ctx, productIds)
+}
@@ -1,25 +1,21 @@
 package helper
 
 import (
-	"errors"
-
 	"clodeo.tech/public/go-universe/pkg/localization"
 )
 
 func GetErrorDataNotFoundWithParam(localizer localization.Localizer, langId string, recordType string) error {
-	lRecordType := localizer.Localize(langId, recordType, nil)
-	return errors.New(localizer.Localize(langId, "ErrorRecordNotFoundWithParam",
+	return NewLocalizedErr(localizer, langId, ErrorKindNotFound, "ErrorRecordNotFoundWithParam",
 		map[string]interface{}{
-			"FieldName": lRecordType,
+			"FieldName": MessageParam(recordType),
 		},
-	))
+	)
 }
 
 func GetErrorDateFieldValueIsFutureDate(localizer localization.Localizer, langId string, fieldName string) error {
-	lFieldName := localizer.Localize(langId, fieldName, nil)
-	return errors.New(localizer.Localize(langId, "ErrorInvalidDateValueUsingFutureDate",
+	return NewLocalizedErr(localizer, langId, ErrorKindBadRequest, "ErrorInvalidDateValueUsingFutureDate",
 		map[string]interface{}{
-			"FieldName": lFieldName,
+			"FieldName": MessageParam(fieldName),
 		},
-	))
+	)
 }
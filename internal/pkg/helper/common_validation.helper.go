@@ -1,7 +1,6 @@
 package helper
 
 import (
-	"errors"
 	"strconv"
 	"strings"
 	"time"
@@ -55,11 +54,11 @@ func ValidateDateOfBirth(localizer localization.Localizer, langId string, d time
 
 	if aDate.After(maxDate) {
 		if minAge > 0 {
-			return errors.New(localizer.Localize(langId, "ErrorDateOfBirthBelowMinAge",
+			return NewLocalizedErr(localizer, langId, ErrorKindBadRequest, "ErrorDateOfBirthBelowMinAge",
 				map[string]interface{}{
 					"MinAge": minAge,
 				},
-			))
+			)
 		}
 
 		return GetErrorDateFieldValueIsFutureDate(localizer, langId, "DateOfBirth")
@@ -1,6 +1,10 @@
 package excel
 
+import "io"
+
 type Excel interface {
 	Export(filename string, data Sheet) error
 	ExportMultipleSheet(filename string, sheets []Sheet) error
+	ExportToWriter(w io.Writer, sheets []Sheet) error
+	Import(r io.Reader, opts ImportOpts) (*ImportResult, error)
 }
@@ -7,4 +7,8 @@ var (
 	ErrDataSheetIsRequired = errors.New("data sheet is required")
 	// ErrHeaderAndColumnOrderNotValid defined the error message on invalid header and column order data
 	ErrHeaderAndColumnOrderNotValid = errors.New("header and column order is not valid")
+	// ErrFileFormatNotSupported defined the error message on importing an unknown file format
+	ErrFileFormatNotSupported = errors.New("file format is not supported")
+	// ErrHeaderRowIsRequired defined the error message on importing a file without header row
+	ErrHeaderRowIsRequired = errors.New("header row is required")
 )
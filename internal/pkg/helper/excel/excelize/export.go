@@ -1,6 +1,8 @@
 package excelize
 
 import (
+	"io"
+
 	"github.com/xuri/excelize/v2"
 
 	"gobase/internal/pkg/helper/excel"
@@ -38,6 +40,33 @@ func (h Excel) ExportMultipleSheet(filename string, sheets []excel.Sheet) error
 	return nil
 }
 
+func (h Excel) ExportToWriter(w io.Writer, sheets []excel.Sheet) error {
+	f := excelize.NewFile()
+
+	if len(sheets) <= 0 {
+		return ErrDataSheetIsRequired
+	}
+	for _, sheet := range sheets {
+		if len(sheet.Data) <= 0 {
+			return ErrDataSheetIsRequired
+		}
+	}
+
+	for _, sheet := range sheets {
+		err := generateSheet(f, sheet)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := f.DeleteSheet("Sheet1")
+	if err != nil {
+		return err
+	}
+
+	return f.Write(w)
+}
+
 func (h Excel) Export(filename string, sheet excel.Sheet) error {
 	f := excelize.NewFile()
 
@@ -0,0 +1,101 @@
+package excelize
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"gobase/internal/pkg/helper/excel"
+)
+
+func (h Excel) Import(r io.Reader, opts excel.ImportOpts) (*excel.ImportResult, error) {
+	var (
+		records [][]string
+		err     error
+	)
+
+	switch opts.Format {
+	case excel.FileFormatCSV:
+		records, err = readCSV(r)
+	case excel.FileFormatXLSX, "":
+		records, err = readXLSX(r, opts.SheetName)
+	default:
+		return nil, ErrFileFormatNotSupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, ErrHeaderRowIsRequired
+	}
+
+	headers := records[0]
+	columnKeys := make([]excel.HeaderKey, len(headers))
+	for i, header := range headers {
+		key, ok := opts.Mapping[excel.NormalizeHeader(header)]
+		if !ok {
+			continue
+		}
+		columnKeys[i] = key
+	}
+
+	result := &excel.ImportResult{
+		Headers: headers,
+	}
+
+	for i, record := range records[1:] {
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		row := excel.ImportRow{
+			Number: i + 2,
+			Cells:  record,
+			Values: make(map[excel.HeaderKey]string),
+		}
+		for col, value := range record {
+			if col >= len(columnKeys) || columnKeys[col] == "" {
+				continue
+			}
+			row.Values[columnKeys[col]] = value
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result, nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	return reader.ReadAll()
+}
+
+func readXLSX(r io.Reader, sheetName string) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	if sheetName == "" {
+		sheetName = f.GetSheetName(0)
+	}
+
+	return f.GetRows(sheetName)
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package excel
+
+import "strings"
+
+// FileFormat is the spreadsheet format of an imported file.
+type FileFormat string
+
+const (
+	FileFormatXLSX FileFormat = "xlsx"
+	FileFormatCSV  FileFormat = "csv"
+)
+
+// FileFormatFromFilename guesses the file format from the file extension. It defaults to XLSX.
+func FileFormatFromFilename(filename string) FileFormat {
+	if strings.HasSuffix(strings.ToLower(filename), ".csv") {
+		return FileFormatCSV
+	}
+	return FileFormatXLSX
+}
+
+// HeaderMapping maps a header label in the imported file to a HeaderKey. Labels are matched case-insensitively.
+type HeaderMapping map[string]HeaderKey
+
+type ImportOpts struct {
+	Format    FileFormat
+	SheetName string // XLSX only, defaults to the first sheet
+	Mapping   HeaderMapping
+}
+
+// ImportRow is a single data row of an imported file.
+// Number is the 1-based row number in the file, including the header row.
+type ImportRow struct {
+	Number int
+	Cells  []string
+	Values map[HeaderKey]string
+}
+
+// Value returns the trimmed value of a mapped column, or an empty string if the column is not mapped.
+func (r ImportRow) Value(key HeaderKey) string {
+	return strings.TrimSpace(r.Values[key])
+}
+
+type ImportResult struct {
+	Headers []string
+	Rows    []ImportRow
+}
+
+// NormalizeHeader returns the header label used for mapping lookups.
+func NormalizeHeader(header string) string {
+	return strings.ToLower(strings.TrimSpace(header))
+}
@@ -0,0 +1,96 @@
+package language
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the language of the content stored on the records themselves. Content in other
+// languages is stored as translations and falls back to the default language when missing.
+const Default = "id"
+
+// Supported lists the languages that have a locale file in resource/locale.
+var Supported = []string{"en", "id"}
+
+type contextKey string
+
+const languageKey = contextKey("language")
+
+// IsSupported reports whether content can be stored and requested in the language.
+func IsSupported(lang string) bool {
+	for _, supported := range Supported {
+		if supported == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// WithContext returns a copy of ctx that carries the requested language.
+func WithContext(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey, lang)
+}
+
+// FromContext returns the requested language, or the default language when none was requested.
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey).(string); ok && lang != "" {
+		return lang
+	}
+	return Default
+}
+
+// Normalize turns a language tag such as "en-US" into a supported language, or returns an empty string.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if base, _, found := strings.Cut(tag, "-"); found {
+		tag = base
+	}
+	if IsSupported(tag) {
+		return tag
+	}
+	return ""
+}
+
+// ParseAcceptLanguage returns the supported language the client prefers the most in an Accept-Language
+// header, e.g. "en-US,en;q=0.9,id;q=0.8". It returns an empty string when none is supported.
+func ParseAcceptLanguage(header string) string {
+	type preference struct {
+		lang    string
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		lang := Normalize(tag)
+		if lang == "" {
+			continue
+		}
+
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		preferences = append(preferences, preference{lang: lang, quality: quality})
+	}
+
+	if len(preferences) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+
+	return preferences[0].lang
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"clodeo.tech/public/go-universe/pkg/localization"
+)
+
+// ErrorKind tells the transports how to present a LocalizedErr to clients.
+type ErrorKind string
+
+const (
+	ErrorKindBadRequest ErrorKind = "bad_request"
+	ErrorKindNotFound   ErrorKind = "not_found"
+	ErrorKindConflict   ErrorKind = "conflict"
+	ErrorKindForbidden  ErrorKind = "forbidden"
+)
+
+// MessageParam is a param of a LocalizedErr that is itself a message id, e.g. a field name,
+// so it is localized in the same language as the message.
+type MessageParam string
+
+// LocalizedErr is an error meant for clients. Its message is localized once more in the language of the
+// request it is presented to, as the use cases localize it before they know who asked.
+type LocalizedErr struct {
+	Kind      ErrorKind
+	MessageId string
+	Params    map[string]interface{}
+
+	message string
+}
+
+// NewLocalizedErr creates an error of a kind with a message localized in langId.
+func NewLocalizedErr(localizer localization.Localizer, langId string, kind ErrorKind, messageId string, params map[string]interface{}) error {
+	err := &LocalizedErr{
+		Kind:      kind,
+		MessageId: messageId,
+		Params:    params,
+	}
+	err.message = err.Localize(localizer, langId)
+	return err
+}
+
+func (e *LocalizedErr) Error() string {
+	return e.message
+}
+
+// Localize returns the message of the error in langId.
+func (e *LocalizedErr) Localize(localizer localization.Localizer, langId string) string {
+	var params map[string]interface{}
+	if e.Params != nil {
+		params = make(map[string]interface{}, len(e.Params))
+		for key, value := range e.Params {
+			if messageId, ok := value.(MessageParam); ok {
+				value = localizer.Localize(langId, string(messageId), nil)
+			}
+			params[key] = value
+		}
+	}
+	return localizer.Localize(langId, e.MessageId, params)
+}
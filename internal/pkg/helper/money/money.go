@@ -0,0 +1,91 @@
+package money
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrency is the currency used when none is given.
+const DefaultCurrency = "IDR"
+
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+// Money is an exact amount in an ISO 4217 currency.
+type Money struct {
+	Amount   decimal.Decimal `json:"amount" validate:"gte=0"`
+	Currency string          `json:"currency" validate:"iso4217"`
+}
+
+// New creates a Money of the given amount and currency. The currency code is upper cased.
+func New(amount decimal.Decimal, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: strings.ToUpper(currency),
+	}
+}
+
+// Parse creates a Money from a decimal string, e.g. "15000.50".
+func Parse(amount string, currency string) (Money, error) {
+	value, err := decimal.NewFromString(strings.TrimSpace(amount))
+	if err != nil {
+		return Money{}, err
+	}
+	return New(value, currency), nil
+}
+
+// Zero returns a zero amount in the given currency.
+func Zero(currency string) Money {
+	return New(decimal.Zero, currency)
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// Round rounds the amount to the minor units of its currency, using banker's rounding. The currency code is upper
+// cased, as inputs may carry it in any case.
+func (m Money) Round() Money {
+	currency := strings.ToUpper(m.Currency)
+	return Money{
+		Amount:   m.Amount.RoundBank(MinorUnits(currency)),
+		Currency: currency,
+	}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount.Sub(other.Amount), Currency: m.Currency}, nil
+}
+
+// Mul multiplies the amount by a quantity.
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount.Mul(decimal.NewFromInt(quantity)), Currency: m.Currency}
+}
+
+// Cmp compares two amounts of the same currency. It returns -1, 0 or 1.
+func (m Money) Cmp(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, ErrCurrencyMismatch
+	}
+	return m.Amount.Cmp(other.Amount), nil
+}
+
+func (m Money) Equal(other Money) bool {
+	return m.Currency == other.Currency && m.Amount.Equal(other.Amount)
+}
+
+// String formats the amount with the minor units of its currency, e.g. "IDR 15000" or "USD 12.50".
+func (m Money) String() string {
+	return m.Currency + " " + m.Amount.StringFixedBank(MinorUnits(m.Currency))
+}
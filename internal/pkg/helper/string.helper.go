@@ -0,0 +1,26 @@
+package helper
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify converts a text into a lowercase, URL friendly slug, e.g. "Men's T-Shirts" becomes "men-s-t-shirts".
+func Slugify(text string) string {
+	var b strings.Builder
+	lastDash := true
+
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastDash = false
+			continue
+		}
+		if !lastDash {
+			b.WriteRune('-')
+			lastDash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
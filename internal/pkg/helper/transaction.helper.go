@@ -1,7 +1,6 @@
 package helper
 
 import (
-	"errors"
 	"fmt"
 
 	"clodeo.tech/public/go-universe/pkg/localization"
@@ -14,7 +13,7 @@ func UpdateDeleteWithRowsAffectedWrapper(localizer localization.Localizer, langI
 	}
 
 	if rowsAffected == 0 {
-		return errors.New(localizer.Localize(langId, "ErrorRecordNotFoundOrHasBeenChanged", nil))
+		return NewLocalizedErr(localizer, langId, ErrorKindConflict, "ErrorRecordNotFoundOrHasBeenChanged", nil)
 	}
 
 	return nil
@@ -0,0 +1,53 @@
+package middlewaregraphql
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/rs/zerolog/log"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"gobase/internal/pkg/service/authsvc"
+)
+
+// ErrUnauthenticated is the error code of the requests whose credentials are not valid.
+const ErrUnauthenticated = "UNAUTHENTICATED"
+
+type Authentication func(next http.Handler) http.Handler
+
+// NewAuthentication authenticates the requests holding a bearer JWT or an API key and stores their principal in the
+// request context. Requests without credentials go on anonymously, and the ones with invalid credentials are refused.
+func NewAuthentication(authenticator authsvc.Authenticator) Authentication {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authenticator.Authenticate(r.Context(), authsvc.CredentialsFromHeaders(r.Header.Get))
+			switch {
+			case errors.Is(err, authsvc.ErrNoCredentials):
+			case err != nil:
+				log.Debug().Err(err).Msg("graphql request is not authenticated")
+				writeUnauthenticated(w)
+				return
+			default:
+				trace.SpanFromContext(r.Context()).SetAttributes(
+					attribute.String("enduser.id", principal.Subject),
+					attribute.String("enduser.tenant", principal.Tenant),
+				)
+				r = r.WithContext(authsvc.WithPrincipal(r.Context(), principal))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeUnauthenticated(w http.ResponseWriter) {
+	gqlErr := gqlerror.Errorf("the credentials of the request are not valid")
+	errcode.Set(gqlErr, ErrUnauthenticated)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errors": gqlerror.List{gqlErr}})
+}
@@ -2,9 +2,12 @@ package middlewaregraphql
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/vektah/gqlparser/v2/ast"
 
 	"gobase/di/registry"
 )
@@ -13,18 +16,54 @@ type contextKey string
 
 const loadersKey = contextKey("dataloaders")
 
+// ErrDataloaderMissing is returned by For when the request did not go through the dataloader middleware.
+var ErrDataloaderMissing = errors.New("graphql dataloaders are missing from the request context")
+
 type Dataloader = func(srv *handler.Server) http.Handler
 
-func NewDataloader(loader registry.GraphQLDataloader) Dataloader {
+// NewDataloader creates a fresh set of dataloaders for every request, so that nothing they cache outlives the request.
+// A subscription lives as long as its WebSocket connection, so it gets a fresh set for every event instead.
+func NewDataloader(factory registry.GraphQLDataloaderFactory) Dataloader {
 	return func(srv *handler.Server) http.Handler {
+		srv.Use(subscriptionDataloader{factory: factory})
+
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), loadersKey, loader)
+			ctx := context.WithValue(r.Context(), loadersKey, factory())
 			srv.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// subscriptionDataloader replaces the dataloaders of the connection with fresh ones for every subscription event.
+type subscriptionDataloader struct {
+	factory registry.GraphQLDataloaderFactory
+}
+
+var _ interface {
+	graphql.ResponseInterceptor
+	graphql.HandlerExtension
+} = subscriptionDataloader{}
+
+func (d subscriptionDataloader) ExtensionName() string {
+	return "SubscriptionDataloader"
+}
+
+func (d subscriptionDataloader) Validate(_ graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d subscriptionDataloader) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if graphql.HasOperationContext(ctx) && graphql.GetOperationContext(ctx).Operation.Operation == ast.Subscription {
+		ctx = context.WithValue(ctx, loadersKey, d.factory())
+	}
+	return next(ctx)
+}
+
 // For returns the dataloaders for the current request.
-func For(ctx context.Context) registry.GraphQLDataloader {
-	return ctx.Value(loadersKey).(registry.GraphQLDataloader)
+func For(ctx context.Context) (registry.GraphQLDataloader, error) {
+	loaders, ok := ctx.Value(loadersKey).(registry.GraphQLDataloader)
+	if !ok {
+		return registry.GraphQLDataloader{}, ErrDataloaderMissing
+	}
+	return loaders, nil
 }
@@ -0,0 +1,22 @@
+package middlewaregraphql
+
+import (
+	"net/http"
+
+	"gobase/internal/pkg/helper/language"
+)
+
+type Language = func(next http.Handler) http.Handler
+
+// NewLanguage stores the language requested through the Accept-Language header in the request context.
+// A lang argument on a query takes precedence over it.
+func NewLanguage() Language {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if lang := language.ParseAcceptLanguage(r.Header.Get("Accept-Language")); lang != "" {
+				r = r.WithContext(language.WithContext(r.Context(), lang))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
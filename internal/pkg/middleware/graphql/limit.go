@@ -0,0 +1,160 @@
+package middlewaregraphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"gobase/config"
+)
+
+const (
+	defaultMaxDepth      = 12
+	defaultMaxComplexity = 5000
+
+	// ErrDepthLimit is the error code of the operations rejected for their depth.
+	ErrDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+	// ErrComplexityLimit is the error code of the operations rejected for their complexity.
+	ErrComplexityLimit = "COMPLEXITY_LIMIT_EXCEEDED"
+
+	depthExtension = "DepthLimit"
+)
+
+// Limit rejects the operations deeper or more complex than the limits of the GraphQL server config.
+// It is installed after Otel, so the depth is recorded on the operation span next to the complexity.
+// Unlike Otel it is a defined type, since wire tells the providers apart by their types.
+type Limit func(srv *handler.Server)
+
+func NewLimit(cfg *config.MainConfig) Limit {
+	maxDepth := cfg.Server.GraphQL.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
+	}
+	maxComplexity := cfg.Server.GraphQL.MaxComplexity
+	if maxComplexity <= 0 {
+		maxComplexity = defaultMaxComplexity
+	}
+
+	return func(srv *handler.Server) {
+		srv.Use(DepthLimit{Limit: maxDepth})
+		srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	}
+}
+
+// DepthStats is the depth of an operation, recorded in the operation context stats.
+type DepthStats struct {
+	Depth      int
+	DepthLimit int
+}
+
+// DepthLimit rejects the operations nesting fields deeper than its limit. Introspection fields are not counted.
+type DepthLimit struct {
+	Limit int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.ResponseInterceptor
+	graphql.HandlerExtension
+} = DepthLimit{}
+
+func (d DepthLimit) ExtensionName() string {
+	return depthExtension
+}
+
+func (d DepthLimit) Validate(_ graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	depth := selectionSetDepth(opCtx.Operation.SelectionSet)
+
+	opCtx.Stats.SetExtension(depthExtension, &DepthStats{
+		Depth:      depth,
+		DepthLimit: d.Limit,
+	})
+
+	if depth > d.Limit {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Limit)
+		errcode.Set(err, ErrDepthLimit)
+		return err
+	}
+
+	return nil
+}
+
+// InterceptResponse records the depth of the operation on its span.
+func (d DepthLimit) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+
+	if stats, ok := graphql.GetOperationContext(ctx).Stats.GetExtension(depthExtension).(*DepthStats); ok {
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int("gql.request.depthLimit", stats.DepthLimit),
+			attribute.Int("gql.request.operationDepth", stats.Depth),
+		)
+	}
+
+	return next(ctx)
+}
+
+// selectionSetDepth returns the number of nested fields of the deepest field of a selection set.
+func selectionSetDepth(selectionSet ast.SelectionSet) int {
+	w := depthWalker{
+		fragments: make(map[string]int),
+		visiting:  make(map[string]bool),
+	}
+	return w.depth(selectionSet)
+}
+
+// depthWalker measures the depth of selection sets, walking each fragment once however many times it is spread.
+// A fragment spread within itself, which the validation rejects anyway, adds no depth instead of recursing forever.
+type depthWalker struct {
+	fragments map[string]int
+	visiting  map[string]bool
+}
+
+func (w *depthWalker) depth(selectionSet ast.SelectionSet) int {
+	depth := 0
+	for _, selection := range selectionSet {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			depth = max(depth, 1+w.depth(s.SelectionSet))
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				depth = max(depth, w.fragmentDepth(s.Definition))
+			}
+		case *ast.InlineFragment:
+			depth = max(depth, w.depth(s.SelectionSet))
+		}
+	}
+	return depth
+}
+
+func (w *depthWalker) fragmentDepth(fragment *ast.FragmentDefinition) int {
+	if depth, ok := w.fragments[fragment.Name]; ok {
+		return depth
+	}
+	if w.visiting[fragment.Name] {
+		return 0
+	}
+
+	w.visiting[fragment.Name] = true
+	depth := w.depth(fragment.SelectionSet)
+	delete(w.visiting, fragment.Name)
+
+	w.fragments[fragment.Name] = depth
+	return depth
+}
@@ -0,0 +1,179 @@
+package middlewaregraphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/rs/zerolog/log"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"gobase/config"
+	"gobase/internal/pkg/service/authsvc"
+)
+
+const (
+	defaultTrustedDocumentsReloadInterval = 5 * time.Second
+	automaticPersistedQueryCacheSize      = 100
+
+	// ErrPersistedQueryNotFound is the error code of the operations sent by a hash that is not in the manifest.
+	ErrPersistedQueryNotFound = "PERSISTED_QUERY_NOT_FOUND"
+	// ErrTrustedDocumentRequired is the error code of the ad hoc operations of callers that are not internal.
+	ErrTrustedDocumentRequired = "TRUSTED_DOCUMENT_REQUIRED"
+
+	trustedDocumentsExtension = "TrustedDocuments"
+
+	trustedDocumentMissUnknownHash     = "unknown_hash"
+	trustedDocumentMissUntrustedQuery  = "untrusted_document"
+	trustedDocumentMissesMetric        = "graphql.trusted_documents.misses"
+	trustedDocumentMissesMetricDesc    = "Operations rejected because their document is not in the trusted document manifest."
+	trustedDocumentMissReasonAttribute = "reason"
+)
+
+// TrustedDocuments restricts the operations of the GraphQL server to the documents of the manifest of the GraphQL
+// server config, sent in full or by the sha256Hash of the persistedQuery extension. Internal callers, whose principal
+// has the internal role, can still send ad hoc operations. When it is disabled, any operation is executed and
+// clients can register theirs with automatic persisted queries.
+type TrustedDocuments struct {
+	enabled  bool
+	manifest *TrustedDocumentManifest
+	misses   metric.Int64Counter
+}
+
+// NewTrustedDocuments loads the manifest of the trusted documents and reloads it whenever its file changes, until
+// the returned cleanup is called.
+func NewTrustedDocuments(cfg *config.MainConfig) (*TrustedDocuments, func(), error) {
+	trustedCfg := cfg.Server.GraphQL.TrustedDocuments
+	if !trustedCfg.Enabled {
+		return &TrustedDocuments{}, func() {}, nil
+	}
+
+	manifest, err := LoadTrustedDocumentManifest(trustedCfg.ManifestPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	misses, err := otel.Meter("gobase/graphql").Int64Counter(
+		trustedDocumentMissesMetric,
+		metric.WithDescription(trustedDocumentMissesMetricDesc),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reloadInterval := time.Duration(trustedCfg.ReloadIntervalMs) * time.Millisecond
+	if reloadInterval <= 0 {
+		reloadInterval = defaultTrustedDocumentsReloadInterval
+	}
+
+	stop := make(chan struct{})
+	go reloadTrustedDocumentManifest(manifest, reloadInterval, stop)
+
+	trustedDocuments := &TrustedDocuments{
+		enabled:  true,
+		manifest: manifest,
+		misses:   misses,
+	}
+
+	return trustedDocuments, func() { close(stop) }, nil
+}
+
+// Install adds the extension resolving the operations of the server.
+func (t *TrustedDocuments) Install(srv *handler.Server) {
+	if !t.enabled {
+		srv.Use(extension.AutomaticPersistedQuery{
+			Cache: lru.New[string](automaticPersistedQueryCacheSize),
+		})
+		return
+	}
+
+	srv.Use(t)
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = &TrustedDocuments{}
+
+func (t *TrustedDocuments) ExtensionName() string {
+	return trustedDocumentsExtension
+}
+
+func (t *TrustedDocuments) Validate(_ graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (t *TrustedDocuments) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if rawParams.Query == "" {
+		hash := persistedQueryHash(rawParams)
+		if hash == "" {
+			return nil
+		}
+
+		document, ok := t.manifest.Document(hash)
+		if !ok {
+			t.recordMiss(ctx, trustedDocumentMissUnknownHash)
+			err := gqlerror.Errorf("PersistedQueryNotFound")
+			errcode.Set(err, ErrPersistedQueryNotFound)
+			return err
+		}
+
+		rawParams.Query = document
+		return nil
+	}
+
+	if t.manifest.Contains(rawParams.Query) {
+		return nil
+	}
+	if principal, ok := authsvc.FromContext(ctx); ok && principal.HasRole(authsvc.RoleInternal) {
+		return nil
+	}
+
+	t.recordMiss(ctx, trustedDocumentMissUntrustedQuery)
+	err := gqlerror.Errorf("only trusted documents can be executed, send the hash of the operation instead")
+	errcode.Set(err, ErrTrustedDocumentRequired)
+	return err
+}
+
+func (t *TrustedDocuments) recordMiss(ctx context.Context, reason string) {
+	t.misses.Add(ctx, 1, metric.WithAttributes(attribute.String(trustedDocumentMissReasonAttribute, reason)))
+}
+
+// persistedQueryHash returns the sha256Hash of the persistedQuery extension of the request, as sent by Apollo clients.
+func persistedQueryHash(rawParams *graphql.RawParams) string {
+	persistedQuery, ok := rawParams.Extensions["persistedQuery"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	hash, _ := persistedQuery["sha256Hash"].(string)
+	return hash
+}
+
+// reloadTrustedDocumentManifest checks whether the file of the manifest changed every interval until stop is closed.
+func reloadTrustedDocumentManifest(manifest *TrustedDocumentManifest, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			reloaded, err := manifest.Reload()
+			if err != nil {
+				log.Error().Err(err).Str("path", manifest.path).Msg("failed to reload the trusted document manifest")
+				continue
+			}
+			if reloaded {
+				log.Info().Str("path", manifest.path).Int("documents", manifest.Len()).Msg("reloaded the trusted document manifest")
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package middlewaregraphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const apolloManifestFormat = "apollo-persisted-query-manifest"
+
+// ErrInvalidTrustedDocumentManifest is returned when a manifest file is neither a hash to document object nor an
+// Apollo persisted query manifest.
+var ErrInvalidTrustedDocumentManifest = errors.New("invalid trusted document manifest")
+
+// TrustedDocumentManifest holds the operations the clients were built with, by the hash of their document.
+// It is generated at build time, either as a JSON object of hashes to documents or as an Apollo persisted query
+// manifest, and is reloaded when its file changes.
+type TrustedDocumentManifest struct {
+	path string
+
+	mu        sync.RWMutex
+	byHash    map[string]string
+	documents map[string]struct{}
+	modTime   time.Time
+	size      int64
+}
+
+// apolloManifest is the persisted query manifest generated by Apollo's generate-persisted-query-manifest.
+type apolloManifest struct {
+	Format     string `json:"format"`
+	Operations []struct {
+		Id   string `json:"id"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// LoadTrustedDocumentManifest loads the manifest of a file.
+func LoadTrustedDocumentManifest(path string) (*TrustedDocumentManifest, error) {
+	manifest := &TrustedDocumentManifest{path: path}
+	if _, err := manifest.Reload(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Reload loads the file of the manifest again if it was modified since it was last loaded, and tells whether it was.
+// The loaded documents are kept when the file can not be read or parsed.
+func (m *TrustedDocumentManifest) Reload() (bool, error) {
+	stat, err := os.Stat(m.path)
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.RLock()
+	unchanged := stat.ModTime().Equal(m.modTime) && stat.Size() == m.size
+	m.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return false, err
+	}
+
+	byHash, err := parseTrustedDocumentManifest(data)
+	if err != nil {
+		return false, err
+	}
+
+	documents := make(map[string]struct{}, len(byHash))
+	for _, document := range byHash {
+		documents[document] = struct{}{}
+	}
+
+	m.mu.Lock()
+	m.byHash = byHash
+	m.documents = documents
+	m.modTime = stat.ModTime()
+	m.size = stat.Size()
+	m.mu.Unlock()
+
+	return true, nil
+}
+
+// Document returns the document of a hash.
+func (m *TrustedDocumentManifest) Document(hash string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	document, ok := m.byHash[hash]
+	return document, ok
+}
+
+// Contains tells whether a document is one of the manifest, as sent by the clients that send documents in full.
+func (m *TrustedDocumentManifest) Contains(document string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.documents[document]
+	return ok
+}
+
+// Len returns the number of documents of the manifest.
+func (m *TrustedDocumentManifest) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.byHash)
+}
+
+func parseTrustedDocumentManifest(data []byte) (map[string]string, error) {
+	var apollo apolloManifest
+	if err := json.Unmarshal(data, &apollo); err == nil && apollo.Format == apolloManifestFormat {
+		byHash := make(map[string]string, len(apollo.Operations))
+		for _, operation := range apollo.Operations {
+			if operation.Id == "" || operation.Body == "" {
+				return nil, fmt.Errorf("%w: operation without an id or a body", ErrInvalidTrustedDocumentManifest)
+			}
+			byHash[operation.Id] = operation.Body
+		}
+		return byHash, nil
+	}
+
+	var byHash map[string]string
+	if err := json.Unmarshal(data, &byHash); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTrustedDocumentManifest, err)
+	}
+	return byHash, nil
+}
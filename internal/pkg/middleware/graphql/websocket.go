@@ -0,0 +1,45 @@
+package middlewaregraphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/service/authsvc"
+)
+
+// ErrWebsocketUnauthenticated is returned when a WebSocket connection does not init with valid credentials.
+var ErrWebsocketUnauthenticated = errors.New("websocket connection is not authenticated")
+
+type WebsocketInit = transport.WebsocketInitFunc
+
+// NewWebsocketInit authenticates the WebSocket connections of subscriptions on their connection_init message, whose
+// payload stands in for the HTTP headers browsers can not send on the upgrade. It holds an API key as its apiKey, or
+// a JWT or an API key as a bearer Authorization. The payload can also hold an Accept-Language for the connection.
+// Connections already authenticated by the headers of their upgrade request are accepted as they are.
+func NewWebsocketInit(authenticator authsvc.Authenticator) WebsocketInit {
+	return func(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		if _, ok := authsvc.FromContext(ctx); !ok {
+			credentials := authsvc.CredentialsFromHeaders(func(key string) string {
+				if key == authsvc.APIKeyHeader {
+					return initPayload.GetString("apiKey")
+				}
+				return initPayload.Authorization()
+			})
+
+			principal, err := authenticator.Authenticate(ctx, credentials)
+			if err != nil {
+				return ctx, nil, ErrWebsocketUnauthenticated
+			}
+			ctx = authsvc.WithPrincipal(ctx, principal)
+		}
+
+		if lang := language.ParseAcceptLanguage(initPayload.GetString("Accept-Language")); lang != "" {
+			ctx = language.WithContext(ctx, lang)
+		}
+
+		return ctx, nil, nil
+	}
+}
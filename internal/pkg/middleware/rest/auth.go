@@ -0,0 +1,28 @@
+package middlewarerest
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/service/authsvc"
+)
+
+// GetAuthMiddleware authenticates the requests holding a bearer JWT or an API key and stores their principal in the
+// user context. Requests without credentials go on anonymously, and the ones with invalid credentials are refused.
+func GetAuthMiddleware(authenticator authsvc.Authenticator) fiber.Handler {
+	return func(fc *fiber.Ctx) error {
+		credentials := authsvc.CredentialsFromHeaders(func(key string) string { return fc.Get(key) })
+		principal, err := authenticator.Authenticate(fc.UserContext(), credentials)
+		if errors.Is(err, authsvc.ErrNoCredentials) {
+			return fc.Next()
+		}
+		if err != nil {
+			return helper.NewUnauthorizedErr(err, "the credentials of the request are not valid")
+		}
+
+		fc.SetUserContext(authsvc.WithPrincipal(fc.UserContext(), principal))
+		return fc.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package authsvc
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"gobase/config"
+)
+
+// APIKeyAuthenticator authenticates the callers holding one of the static API keys of the auth config, in the
+// X-Api-Key header or as a bearer Authorization that is not a JWT.
+type APIKeyAuthenticator struct {
+	keys []config.AuthAPIKeyConfig
+}
+
+func NewAPIKeyAuthenticator(keys []config.AuthAPIKeyConfig) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{keys: keys}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(_ context.Context, credentials Credentials) (*Principal, error) {
+	apiKey := credentials.APIKey
+	if apiKey == "" {
+		apiKey = credentials.BearerToken
+	}
+	if apiKey == "" {
+		return nil, ErrNoCredentials
+	}
+
+	key, ok := a.find(apiKey)
+	if !ok {
+		if credentials.APIKey == "" {
+			// The bearer token may be a JWT for the next authenticator.
+			return nil, ErrNoCredentials
+		}
+		return nil, ErrUnauthenticated
+	}
+
+	return &Principal{
+		Subject: key.Subject,
+		Tenant:  key.Tenant,
+		Roles:   key.Roles,
+		Scopes:  key.Scopes,
+		Method:  MethodAPIKey,
+	}, nil
+}
+
+// find returns the config of an API key, comparing the keys in constant time.
+func (a *APIKeyAuthenticator) find(apiKey string) (config.AuthAPIKeyConfig, bool) {
+	for _, key := range a.keys {
+		if key.Key != "" && subtle.ConstantTimeCompare([]byte(key.Key), []byte(apiKey)) == 1 {
+			return key, true
+		}
+	}
+	return config.AuthAPIKeyConfig{}, false
+}
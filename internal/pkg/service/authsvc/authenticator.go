@@ -0,0 +1,77 @@
+package authsvc
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrNoCredentials is returned by an authenticator when the request holds no credentials it can verify,
+// so the next authenticator is tried and the request is anonymous when none can.
+var ErrNoCredentials = errors.New("no credentials")
+
+// ErrUnauthenticated is returned when the credentials of a request are not valid.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+const (
+	// APIKeyHeader is the header holding an API key.
+	APIKeyHeader = "X-Api-Key"
+	// AuthorizationHeader is the header holding a bearer token, either a JWT or an API key.
+	AuthorizationHeader = "Authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+// Credentials are what a caller sent to authenticate.
+type Credentials struct {
+	// BearerToken is the token of a bearer Authorization.
+	BearerToken string
+	// APIKey is the API key of the X-Api-Key header.
+	APIKey string
+}
+
+// CredentialsFromHeaders reads the credentials of a request with the function returning its header values,
+// e.g. http.Header.Get.
+func CredentialsFromHeaders(header func(key string) string) Credentials {
+	credentials := Credentials{
+		APIKey: strings.TrimSpace(header(APIKeyHeader)),
+	}
+
+	authorization := strings.TrimSpace(header(AuthorizationHeader))
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		credentials.BearerToken = strings.TrimSpace(authorization[len(bearerPrefix):])
+	}
+
+	return credentials
+}
+
+// Empty tells whether no credentials were sent.
+func (c Credentials) Empty() bool {
+	return c.BearerToken == "" && c.APIKey == ""
+}
+
+// Authenticator verifies the credentials of a request and returns its principal. It returns ErrNoCredentials when
+// it can not verify the kind of credentials sent, and an error wrapping ErrUnauthenticated when they are not valid.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials Credentials) (*Principal, error)
+}
+
+// Chain tries the authenticators in order until one verifies the credentials.
+type Chain []Authenticator
+
+func (c Chain) Authenticate(ctx context.Context, credentials Credentials) (*Principal, error) {
+	if credentials.Empty() {
+		return nil, ErrNoCredentials
+	}
+
+	for _, authenticator := range c {
+		principal, err := authenticator.Authenticate(ctx, credentials)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+
+	// Credentials were sent, but none of the authenticators knows them.
+	return nil, ErrUnauthenticated
+}
@@ -0,0 +1,139 @@
+package authsvc
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"gobase/config"
+)
+
+func TestCredentialsFromHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    Credentials
+	}{
+		{
+			name: "no headers",
+		},
+		{
+			name:    "bearer token",
+			headers: map[string]string{AuthorizationHeader: "Bearer a.b.c"},
+			want:    Credentials{BearerToken: "a.b.c"},
+		},
+		{
+			name:    "bearer scheme in any case",
+			headers: map[string]string{AuthorizationHeader: "  bEaReR   a.b.c  "},
+			want:    Credentials{BearerToken: "a.b.c"},
+		},
+		{
+			name:    "other scheme",
+			headers: map[string]string{AuthorizationHeader: "Basic dXNlcjpwYXNz"},
+		},
+		{
+			name:    "bearer without token",
+			headers: map[string]string{AuthorizationHeader: "Bearer "},
+		},
+		{
+			name:    "api key",
+			headers: map[string]string{APIKeyHeader: " key-1 "},
+			want:    Credentials{APIKey: "key-1"},
+		},
+		{
+			name:    "both",
+			headers: map[string]string{APIKeyHeader: "key-1", AuthorizationHeader: "Bearer a.b.c"},
+			want:    Credentials{BearerToken: "a.b.c", APIKey: "key-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.headers {
+				header.Set(key, value)
+			}
+
+			if got := CredentialsFromHeaders(header.Get); got != tt.want {
+				t.Errorf("CredentialsFromHeaders() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainAuthenticate(t *testing.T) {
+	key := generateRSAKey(t)
+	chain := Chain{
+		newTestJWTAuthenticator(t, &key.PublicKey),
+		NewAPIKeyAuthenticator([]config.AuthAPIKeyConfig{{Key: "key-1", Subject: "service-1", Roles: []string{RoleInternal}}}),
+	}
+
+	tests := []struct {
+		name        string
+		credentials Credentials
+		wantSubject string
+		wantMethod  Method
+		wantErr     error
+	}{
+		{
+			name:    "no credentials",
+			wantErr: ErrNoCredentials,
+		},
+		{
+			name:        "valid token",
+			credentials: Credentials{BearerToken: signToken(t, key, testKid, validClaims())},
+			wantSubject: "user-1",
+			wantMethod:  MethodJWT,
+		},
+		{
+			name:        "expired token",
+			credentials: Credentials{BearerToken: signToken(t, key, testKid, withClaim(validClaims(), "exp", int64(0)))},
+			wantErr:     ErrUnauthenticated,
+		},
+		{
+			name:        "unknown kid",
+			credentials: Credentials{BearerToken: signToken(t, key, "other-key", validClaims())},
+			wantErr:     ErrUnauthenticated,
+		},
+		{
+			name:        "opaque bearer token falls through to the api keys",
+			credentials: Credentials{BearerToken: "key-1"},
+			wantSubject: "service-1",
+			wantMethod:  MethodAPIKey,
+		},
+		{
+			name:        "unknown opaque bearer token",
+			credentials: Credentials{BearerToken: "key-2"},
+			wantErr:     ErrUnauthenticated,
+		},
+		{
+			name:        "api key header",
+			credentials: Credentials{APIKey: "key-1"},
+			wantSubject: "service-1",
+			wantMethod:  MethodAPIKey,
+		},
+		{
+			name:        "unknown api key header",
+			credentials: Credentials{APIKey: "key-2"},
+			wantErr:     ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := chain.Authenticate(t.Context(), tt.credentials)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if principal.Subject != tt.wantSubject || principal.Method != tt.wantMethod {
+				t.Errorf("Authenticate() = %+v, want subject %q by %s", principal, tt.wantSubject, tt.wantMethod)
+			}
+		})
+	}
+}
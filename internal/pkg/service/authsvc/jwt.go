@@ -0,0 +1,314 @@
+package authsvc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
+
+	"gobase/config"
+)
+
+const (
+	defaultJWKSRefreshInterval = 5 * time.Minute
+	jwksFetchTimeout           = 10 * time.Second
+
+	defaultTenantClaim = "tenant_id"
+	defaultRolesClaim  = "roles"
+	scopeClaim         = "scope"
+	scpClaim           = "scp"
+)
+
+// ErrInvalidJWKS is returned when a JWKS holds no key JWTs can be verified with.
+var ErrInvalidJWKS = errors.New("invalid JWKS")
+
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// JWTAuthenticator authenticates the callers holding a bearer JWT signed with one of the keys of the JWKS of the
+// auth config, which is loaded from a local file or the endpoint of the identity provider and refreshed periodically.
+type JWTAuthenticator struct {
+	cfg    config.AuthJWTConfig
+	parser *jwt.Parser
+
+	mu   sync.RWMutex
+	keys map[string]any
+
+	stop chan struct{}
+}
+
+// NewJWTAuthenticator loads the JWKS and refreshes it until Close is called.
+func NewJWTAuthenticator(cfg config.AuthJWTConfig) (*JWTAuthenticator, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(jwtSigningMethods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	if cfg.TenantClaim == "" {
+		cfg.TenantClaim = defaultTenantClaim
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = defaultRolesClaim
+	}
+
+	a := &JWTAuthenticator{
+		cfg:    cfg,
+		parser: jwt.NewParser(options...),
+		stop:   make(chan struct{}),
+	}
+	if err := a.refresh(); err != nil {
+		return nil, err
+	}
+
+	refreshInterval := time.Duration(cfg.JWKSRefreshIntervalMs) * time.Millisecond
+	if refreshInterval <= 0 {
+		refreshInterval = defaultJWKSRefreshInterval
+	}
+	go a.refreshEvery(refreshInterval)
+
+	return a, nil
+}
+
+// Close stops refreshing the JWKS.
+func (a *JWTAuthenticator) Close() {
+	close(a.stop)
+}
+
+func (a *JWTAuthenticator) Authenticate(_ context.Context, credentials Credentials) (*Principal, error) {
+	// Opaque bearer tokens are not JWTs, they are left to the API key authenticator.
+	if strings.Count(credentials.BearerToken, ".") != 2 {
+		return nil, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(credentials.BearerToken, claims, a.key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	subject, _ := claims.GetSubject()
+	if subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+	tenant, _ := claims[a.cfg.TenantClaim].(string)
+
+	return &Principal{
+		Subject: subject,
+		Tenant:  tenant,
+		Roles:   stringsClaim(claims[a.cfg.RolesClaim]),
+		Scopes:  scopes(claims),
+		Method:  MethodJWT,
+	}, nil
+}
+
+// key returns the key of the JWKS a token was signed with, by its kid.
+func (a *JWTAuthenticator) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+	// A token without a kid can only be verified when the JWKS has a single key.
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (a *JWTAuthenticator) refreshEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+			if err := a.refresh(); err != nil {
+				log.Error().Err(err).Msg("failed to refresh the JWKS")
+			}
+		}
+	}
+}
+
+// refresh loads the JWKS again. The loaded keys are kept when it can not be loaded.
+func (a *JWTAuthenticator) refresh() error {
+	data, err := a.loadJWKS()
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.mu.Unlock()
+
+	return nil
+}
+
+func (a *JWTAuthenticator) loadJWKS() ([]byte, error) {
+	if a.cfg.JWKSPath != "" {
+		return os.ReadFile(a.cfg.JWKSPath)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching the JWKS of %s: %s", a.cfg.JWKSURL, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the RSA and EC public keys of a JWKS by their kid. Encryption keys are skipped.
+func parseJWKS(data []byte) (map[string]any, error) {
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJWKS, err)
+	}
+
+	keys := make(map[string]any, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := key.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q: %v", ErrInvalidJWKS, key.Kid, err)
+		}
+		if publicKey != nil {
+			keys[key.Kid] = publicKey
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no signing key", ErrInvalidJWKS)
+	}
+	return keys, nil
+}
+
+// publicKey returns the public key of an RSA or EC JWK, or nil for the other key types.
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// scopes returns the scopes of the space separated scope claim, or of the scp claim of the providers using a list.
+func scopes(claims jwt.MapClaims) []string {
+	if scope, ok := claims[scopeClaim].(string); ok {
+		return strings.Fields(scope)
+	}
+	return stringsClaim(claims[scpClaim])
+}
+
+// stringsClaim returns a claim holding a list of strings, or a single one.
+func stringsClaim(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
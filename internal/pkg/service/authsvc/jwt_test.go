@@ -0,0 +1,285 @@
+package authsvc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"gobase/config"
+)
+
+const testKid = "test-key"
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		keys     []map[string]string
+		data     string
+		wantKids []string
+		wantErr  bool
+	}{
+		{
+			name:     "rsa key",
+			keys:     []map[string]string{rsaJWK("rsa", &rsaKey.PublicKey)},
+			wantKids: []string{"rsa"},
+		},
+		{
+			name: "ec key",
+			keys: []map[string]string{{
+				"kty": "EC",
+				"kid": "ec",
+				"crv": "P-256",
+				"x":   encodeBigInt(ecKey.X),
+				"y":   encodeBigInt(ecKey.Y),
+			}},
+			wantKids: []string{"ec"},
+		},
+		{
+			name: "encryption and unknown keys are skipped",
+			keys: []map[string]string{
+				rsaJWK("sig", &rsaKey.PublicKey),
+				withUse(rsaJWK("enc", &rsaKey.PublicKey), "enc"),
+				{"kty": "oct", "kid": "oct", "k": "c2VjcmV0"},
+			},
+			wantKids: []string{"sig"},
+		},
+		{
+			name:    "unsupported curve",
+			keys:    []map[string]string{{"kty": "EC", "kid": "ec", "crv": "P-192", "x": "AQ", "y": "AQ"}},
+			wantErr: true,
+		},
+		{
+			name:    "bad modulus",
+			keys:    []map[string]string{{"kty": "RSA", "kid": "rsa", "n": "not base64!", "e": "AQAB"}},
+			wantErr: true,
+		},
+		{
+			name:    "no signing key",
+			keys:    []map[string]string{withUse(rsaJWK("enc", &rsaKey.PublicKey), "enc")},
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			data:    "{",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(tt.data)
+			if tt.data == "" {
+				data = marshalJWKS(t, tt.keys...)
+			}
+
+			keys, err := parseJWKS(data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidJWKS) {
+					t.Fatalf("parseJWKS() error = %v, want %v", err, ErrInvalidJWKS)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseJWKS() error = %v", err)
+			}
+			if len(keys) != len(tt.wantKids) {
+				t.Fatalf("parseJWKS() returned %d keys, want %d", len(keys), len(tt.wantKids))
+			}
+			for _, kid := range tt.wantKids {
+				if _, ok := keys[kid]; !ok {
+					t.Errorf("parseJWKS() has no key %q", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorAuthenticate(t *testing.T) {
+	key := generateRSAKey(t)
+	authenticator := newTestJWTAuthenticator(t, &key.PublicKey)
+
+	otherKey := generateRSAKey(t)
+
+	tests := []struct {
+		name        string
+		token       string
+		wantSubject string
+		wantErr     error
+	}{
+		{
+			name:        "valid token",
+			token:       signToken(t, key, testKid, validClaims()),
+			wantSubject: "user-1",
+		},
+		{
+			name:    "expired token",
+			token:   signToken(t, key, testKid, withClaim(validClaims(), "exp", time.Now().Add(-time.Minute).Unix())),
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "unknown kid",
+			token:   signToken(t, key, "other-key", validClaims()),
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "signed with another key",
+			token:   signToken(t, otherKey, testKid, validClaims()),
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "no subject",
+			token:   signToken(t, key, testKid, withClaim(validClaims(), "sub", "")),
+			wantErr: ErrUnauthenticated,
+		},
+		{
+			name:    "opaque token",
+			token:   "opaque-api-key",
+			wantErr: ErrNoCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := authenticator.Authenticate(t.Context(), Credentials{BearerToken: tt.token})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if principal.Subject != tt.wantSubject || principal.Method != MethodJWT {
+				t.Errorf("Authenticate() = %+v, want subject %q by JWT", principal, tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorClaims(t *testing.T) {
+	key := generateRSAKey(t)
+	authenticator := newTestJWTAuthenticator(t, &key.PublicKey)
+
+	claims := validClaims()
+	claims["tenant_id"] = "tenant-1"
+	claims["roles"] = []any{"admin", "editor"}
+	claims["scp"] = []any{"products:read"}
+
+	principal, err := authenticator.Authenticate(t.Context(), Credentials{BearerToken: signToken(t, key, testKid, claims)})
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if principal.Tenant != "tenant-1" {
+		t.Errorf("Tenant = %q, want tenant-1", principal.Tenant)
+	}
+	if len(principal.Roles) != 2 || principal.Roles[0] != "admin" || principal.Roles[1] != "editor" {
+		t.Errorf("Roles = %v, want [admin editor]", principal.Roles)
+	}
+	if len(principal.Scopes) != 1 || principal.Scopes[0] != "products:read" {
+		t.Errorf("Scopes = %v, want [products:read]", principal.Scopes)
+	}
+}
+
+func newTestJWTAuthenticator(t *testing.T, publicKey *rsa.PublicKey) *JWTAuthenticator {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, marshalJWKS(t, rsaJWK(testKid, publicKey)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := NewJWTAuthenticator(config.AuthJWTConfig{
+		JWKSPath: path,
+		Issuer:   "https://issuer.test",
+		Audience: "gobase",
+	})
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator() error = %v", err)
+	}
+	t.Cleanup(authenticator.Close)
+
+	return authenticator
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "https://issuer.test",
+		"aud": "gobase",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func withClaim(claims jwt.MapClaims, name string, value any) jwt.MapClaims {
+	claims[name] = value
+	return claims
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"n":   encodeBigInt(key.N),
+		"e":   encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func withUse(jwk map[string]string, use string) map[string]string {
+	jwk["use"] = use
+	return jwk
+}
+
+func marshalJWKS(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
@@ -0,0 +1,102 @@
+package authsvc
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// RoleInternal is the role of the internal callers, e.g. the other services and the back office.
+const RoleInternal = "internal"
+
+// Method is how a principal authenticated.
+type Method string
+
+const (
+	MethodJWT    Method = "jwt"
+	MethodAPIKey Method = "api_key"
+)
+
+const (
+	metadataSubject = "principal_subject"
+	metadataTenant  = "principal_tenant"
+	metadataRoles   = "principal_roles"
+	metadataScopes  = "principal_scopes"
+	metadataMethod  = "principal_method"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string
+	Tenant  string
+	Roles   []string
+	Scopes  []string
+	Method  Method
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx that carries the principal.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of the request, or false when the caller is anonymous.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// HasRole tells whether the principal has the role.
+func (p *Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// HasScope tells whether the principal was granted the scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// MarshalZerologObject adds the principal to a log event, e.g. log.Info().EmbedObject(principal) for audit logs.
+func (p *Principal) MarshalZerologObject(e *zerolog.Event) {
+	e.Str(metadataSubject, p.Subject).
+		Str(metadataTenant, p.Tenant).
+		Strs(metadataRoles, p.Roles).
+		Str(metadataMethod, string(p.Method))
+}
+
+// Metadata returns the principal as the metadata of an event, read back by FromMetadata.
+func (p *Principal) Metadata() map[string]string {
+	return map[string]string{
+		metadataSubject: p.Subject,
+		metadataTenant:  p.Tenant,
+		metadataRoles:   strings.Join(p.Roles, ","),
+		metadataScopes:  strings.Join(p.Scopes, " "),
+		metadataMethod:  string(p.Method),
+	}
+}
+
+// FromMetadata returns the principal of the metadata of an event, or false when it was published anonymously.
+func FromMetadata(metadata map[string]string) (*Principal, bool) {
+	subject := metadata[metadataSubject]
+	if subject == "" {
+		return nil, false
+	}
+
+	return &Principal{
+		Subject: subject,
+		Tenant:  metadata[metadataTenant],
+		Roles:   splitNonEmpty(metadata[metadataRoles], ","),
+		Scopes:  strings.Fields(metadata[metadataScopes]),
+		Method:  Method(metadata[metadataMethod]),
+	}, true
+}
+
+func splitNonEmpty(s string, sep string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, sep)
+}
@@ -0,0 +1,49 @@
+package blobsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Driver is the backend objects are stored in.
+type Driver string
+
+const (
+	DriverLocal Driver = "local"
+	DriverS3    Driver = "s3"
+)
+
+var ErrInvalidSignature = errors.New("blobsvc: invalid or expired signature")
+
+// Storage stores binary objects, such as media files, under a key.
+type Storage interface {
+	// Put stores an object under a key, replacing the object already stored under it.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Delete removes the object stored under a key. Removing a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a URL the object can be downloaded from without credentials until the ttl passes.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+type StorageOpts struct {
+	Driver Driver
+	Local  LocalStorageOpts
+	S3     S3StorageOpts
+}
+
+// NewStorage creates the storage of the configured driver. It defaults to the local filesystem.
+func NewStorage(opts StorageOpts) (Storage, error) {
+	switch opts.Driver {
+	case DriverLocal, "":
+		return NewLocalStorage(opts.Local)
+	case DriverS3:
+		return NewS3Storage(opts.S3)
+	default:
+		return nil, fmt.Errorf("blobsvc: unknown driver %q", opts.Driver)
+	}
+}
@@ -0,0 +1,30 @@
+package blobsvc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrStorageDisabled = errors.New("blobsvc: no storage is configured")
+
+// DisabledStorage stands in for the storage when none is configured. It stores nothing, so objects can not be put
+// nor downloaded, while deleting them succeeds.
+type DisabledStorage struct{}
+
+func NewDisabledStorage() *DisabledStorage {
+	return &DisabledStorage{}
+}
+
+func (s *DisabledStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	return ErrStorageDisabled
+}
+
+func (s *DisabledStorage) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func (s *DisabledStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return "", ErrStorageDisabled
+}
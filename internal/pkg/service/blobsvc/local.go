@@ -0,0 +1,166 @@
+package blobsvc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// LocalRoutePrefix is the path the signed URLs of the local storage point to. The storage serves them itself
+// as an http.Handler mounted on that path.
+const LocalRoutePrefix = "/blobs/"
+
+var ErrNoSigningKey = errors.New("blobsvc: local storage needs a signing key")
+
+// LocalStorage stores objects on the local filesystem. It is meant for development and single node deployments.
+type LocalStorage struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+}
+
+type LocalStorageOpts struct {
+	// Root is the directory the objects are stored in.
+	Root string
+	// BaseURL is the URL of the server the storage handler is mounted on.
+	BaseURL string
+	// SigningKey signs the download URLs.
+	SigningKey string
+}
+
+func NewLocalStorage(opts LocalStorageOpts) (*LocalStorage, error) {
+	if opts.SigningKey == "" {
+		return nil, ErrNoSigningKey
+	}
+
+	err := os.MkdirAll(opts.Root, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{
+		root:       opts.Root,
+		baseURL:    strings.TrimSuffix(opts.BaseURL, "/"),
+		signingKey: []byte(opts.SigningKey),
+	}, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, span := otelsvc.StartSpanWithAttributes(ctx, "BlobStorage/Local/Put", map[string]any{
+		"key":  key,
+		"size": size,
+	})
+	defer span.End()
+
+	filePath := s.path(key)
+	err := os.MkdirAll(filepath.Dir(filePath), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// The object is written next to its final path and moved in place, so a failed upload never leaves a partial file.
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	_, span := otelsvc.StartSpanWithAttributes(ctx, "BlobStorage/Local/Delete", map[string]any{
+		"key": key,
+	})
+	defer span.End()
+
+	err := os.Remove(s.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+
+	escapedKey := (&url.URL{Path: strings.TrimPrefix(path.Clean("/"+key), "/")}).EscapedPath()
+	return fmt.Sprintf("%s%s%s?%s", s.baseURL, LocalRoutePrefix, escapedKey, query.Encode()), nil
+}
+
+// ServeHTTP serves the objects requested through a signed URL.
+func (s *LocalStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, LocalRoutePrefix)
+	expires := r.URL.Query().Get("expires")
+
+	err := s.verify(key, expires, r.URL.Query().Get("signature"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil || stat.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeContent(w, r, stat.Name(), stat.ModTime(), file)
+}
+
+func (s *LocalStorage) verify(key string, expires string, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *LocalStorage) sign(key string, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(path.Clean("/" + key)))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path returns the file of a key. Keys can not point outside of the root directory.
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(path.Clean("/"+key)))
+}
@@ -0,0 +1,79 @@
+package blobsvc
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+// S3Storage stores objects in a bucket of an S3 compatible object storage, e.g. AWS S3 or MinIO.
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+type S3StorageOpts struct {
+	// Endpoint is the host of the object storage, without scheme, e.g. s3.amazonaws.com or localhost:9000.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+}
+
+func NewS3Storage(opts S3StorageOpts) (*S3Storage, error) {
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKeyID, opts.SecretAccessKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Storage{
+		client: client,
+		bucket: opts.Bucket,
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "BlobStorage/S3/Put", map[string]any{
+		"key":  key,
+		"size": size,
+	})
+	defer span.End()
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "BlobStorage/S3/Delete", map[string]any{
+		"key": key,
+	})
+	defer span.End()
+
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "BlobStorage/S3/SignedURL", map[string]any{
+		"key": key,
+	})
+	defer span.End()
+
+	signedURL, err := s.client.PresignedGetObject(ctx, s.bucket, key, ttl, nil)
+	if err != nil {
+		return "", err
+	}
+	return signedURL.String(), nil
+}
@@ -0,0 +1,104 @@
+package broadcastsvc
+
+import (
+	"context"
+	"sync"
+)
+
+// Broker fans the values published to it out to its subscribers. It is in memory, so it only reaches the
+// subscribers of the same instance, e.g. the GraphQL subscriptions connected to it. A Relay feeds the brokers of
+// all the instances.
+type Broker[T any] struct {
+	mu          sync.RWMutex
+	wg          sync.WaitGroup
+	subscribers map[uint64]func(value T)
+	nextId      uint64
+	bufferSize  int
+	closed      bool
+	done        chan struct{}
+}
+
+// NewBroker creates a broker whose subscribers buffer up to bufferSize values.
+func NewBroker[T any](bufferSize int) *Broker[T] {
+	return &Broker[T]{
+		subscribers: make(map[uint64]func(value T)),
+		bufferSize:  max(bufferSize, 0),
+		done:        make(chan struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the published values matched by match, converted to the value it returns.
+// The channel is closed once the context is done or the broker is closed. A value is dropped for a subscriber whose
+// buffer is full, so a slow subscriber never holds the publisher up.
+func Subscribe[T any, R any](ctx context.Context, b *Broker[T], match func(value T) (R, bool)) <-chan R {
+	ch := make(chan R, b.bufferSize)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch
+	}
+
+	id := b.nextId
+	b.nextId++
+	b.subscribers[id] = func(value T) {
+		result, ok := match(value)
+		if !ok {
+			return
+		}
+		select {
+		case ch <- result:
+		default:
+		}
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		select {
+		case <-ctx.Done():
+		case <-b.done:
+		}
+
+		// The channel is closed under the lock, so no publish can be sending to it.
+		b.mu.Lock()
+		delete(b.subscribers, id)
+		close(ch)
+		b.mu.Unlock()
+	}()
+
+	return ch
+}
+
+// Subscribed tells whether the broker has any subscriber, so values nobody receives can be skipped.
+func (b *Broker[T]) Subscribed() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers) > 0
+}
+
+// Publish sends the value to the current subscribers.
+func (b *Broker[T]) Publish(value T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, deliver := range b.subscribers {
+		deliver(value)
+	}
+}
+
+// Close closes the channels of all the subscribers and waits until they are released.
+func (b *Broker[T]) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.done)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
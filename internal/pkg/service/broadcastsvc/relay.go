@@ -0,0 +1,122 @@
+package broadcastsvc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+)
+
+// relayReconnectInterval is how long a relay waits before listening again after losing its connection.
+const relayReconnectInterval = 5 * time.Second
+
+// Relay carries the values published on any instance to the broker of every instance, over a PostgreSQL
+// LISTEN/NOTIFY channel, so the subscribers of all the instances receive them whichever instance consumed the event
+// they come from. The values published while an instance is reconnecting are missed by its subscribers.
+type Relay[T any] struct {
+	db      bun.IDB
+	dsn     string
+	channel string
+	broker  *Broker[T]
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type RelayOpts[T any] struct {
+	// DB is the database the values are notified through.
+	DB bun.IDB
+	// DSN is the DSN of the same database, which the relay keeps a connection of its own to listen with.
+	DSN string
+	// Channel is the name of the notification channel.
+	Channel string
+	// Broker is the broker of this instance, which receives the values published on all the instances.
+	Broker *Broker[T]
+}
+
+// NewRelay starts listening to the channel until Close is called.
+func NewRelay[T any](opts RelayOpts[T]) *Relay[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := &Relay[T]{
+		db:      opts.DB,
+		dsn:     opts.DSN,
+		channel: opts.Channel,
+		broker:  opts.Broker,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go r.listen(ctx)
+
+	return r
+}
+
+// Publish sends the value to the brokers of all the instances, this one included. The value is encoded as JSON,
+// which must fit in the 8000 bytes of a notification payload.
+func (r *Relay[T]) Publish(ctx context.Context, value T) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, "SELECT pg_notify(?, ?)", r.channel, string(payload))
+	return err
+}
+
+// Close stops listening to the channel.
+func (r *Relay[T]) Close() {
+	r.cancel()
+	<-r.done
+}
+
+func (r *Relay[T]) listen(ctx context.Context) {
+	defer close(r.done)
+
+	for {
+		err := r.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Str("channel", r.channel).Msg("lost the broadcast channel, listening again")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(relayReconnectInterval):
+		}
+	}
+}
+
+// listenOnce connects, listens to the channel and hands the notified values to the broker until the connection fails.
+func (r *Relay[T]) listenOnce(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, r.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{r.channel}.Sanitize())
+	if err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		if !r.broker.Subscribed() {
+			continue
+		}
+
+		var value T
+		if err := json.Unmarshal([]byte(notification.Payload), &value); err != nil {
+			log.Warn().Err(err).Str("channel", r.channel).Msg("skipping a broadcast value that can not be decoded")
+			continue
+		}
+		r.broker.Publish(value)
+	}
+}
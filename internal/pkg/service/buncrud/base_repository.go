@@ -20,6 +20,7 @@ type BaseRepository[T any] interface {
 
 	FindAll(ctx context.Context, options *crud.QueryOptions) (*crud.PageResult[T], error)
 	FindIn(ctx context.Context, column string, values []any, options *crud.QueryOptions) ([]*T, error)
+	FindTopIn(ctx context.Context, column string, values []any, limit int, options *crud.QueryOptions) ([]*T, error)
 	FindByID(ctx context.Context, id string) (*T, error)
 	Create(ctx context.Context, entity *T) (*T, error)
 	CreateBulk(ctx context.Context, entities []*T) ([]*T, error)
@@ -69,20 +70,11 @@ func (r *BaseRepositoryImpl[T]) QueryBuilder(ctx context.Context, options *crud.
 	}
 
 	// Apply pagination
-	if opts.Pagination != nil && opts.Pagination.Page > 0 && opts.Pagination.PageSize > 0 {
-		if opts.Pagination.PageSize < 1 {
-			opts.Pagination.PageSize = 10
-		}
-		ApplyPagination(query, opts.Pagination)
-	}
+	ApplyPagination(query, opts.Pagination)
 
 	// Apply sorting
 	for _, s := range opts.Sorts {
-		direction := strings.ToUpper(s.Direction)
-		if direction != "ASC" && direction != "DESC" {
-			direction = "ASC"
-		}
-		query.Order(fmt.Sprintf("%s %s", s.Field, direction))
+		query.Order(sortExpr(s))
 	}
 
 	return query
@@ -176,6 +168,70 @@ func (r *BaseRepositoryImpl[T]) FindIn(ctx context.Context, column string, value
 	return result, nil
 }
 
+// FindTopIn finds, for each of the given values of the column, the first limit entities matching the filters of the
+// options in the order of their sorts. It does so in one query by numbering the entities of each value.
+// A limit of zero or less finds all of them. The entities are returned grouped by value.
+func (r *BaseRepositoryImpl[T]) FindTopIn(ctx context.Context, column string, values []any, limit int, options *crud.QueryOptions) ([]*T, error) {
+	ctx, span := otelsvc.StartSpanWithAttributes(ctx, "Buncrud/FindTopIn", map[string]any{
+		"column": column,
+		"values": values,
+		"limit":  limit,
+	})
+	defer span.End()
+
+	if len(values) == 0 {
+		return []*T{}, nil
+	}
+
+	var filters *crud.FilterGroup
+	var orders []string
+	if options != nil {
+		filters = options.Filters
+		for _, s := range options.Sorts {
+			orders = append(orders, sortExpr(s))
+		}
+	}
+
+	window := "PARTITION BY ?TableAlias.?"
+	if len(orders) > 0 {
+		window += " ORDER BY " + strings.Join(orders, ", ")
+	}
+
+	numbered := r.QueryBuilder(ctx, &crud.QueryOptions{Filters: filters}).
+		ColumnExpr("?TableAlias.*").
+		ColumnExpr("ROW_NUMBER() OVER ("+window+") AS row_number", bun.Ident(column)).
+		Where(fmt.Sprintf("%s IN (?)", column), bun.In(values))
+
+	var entities []T
+	query := r.db.NewSelect().
+		Model(&entities).
+		ModelTableExpr("(?) AS ?TableAlias", numbered).
+		OrderExpr("?TableAlias.? ASC, ?TableAlias.row_number ASC", bun.Ident(column))
+	if limit > 0 {
+		query.Where("?TableAlias.row_number <= ?", limit)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make([]*T, len(entities))
+	for i := range entities {
+		result[i] = &entities[i]
+	}
+
+	return result, nil
+}
+
+// sortExpr returns the ORDER BY expression of a sort. The direction defaults to ascending.
+func sortExpr(s crud.Sort) string {
+	direction := strings.ToUpper(s.Direction)
+	if direction != "ASC" && direction != "DESC" {
+		direction = "ASC"
+	}
+	return fmt.Sprintf("%s %s", s.Field, direction)
+}
+
 // FindByID finds an entity by ID with optional relations.
 // It returns ErrNotFound if the entity is not found.
 func (r *BaseRepositoryImpl[T]) FindByID(ctx context.Context, id string) (*T, error) {
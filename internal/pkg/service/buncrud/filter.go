@@ -9,71 +9,100 @@ import (
 	"gobase/internal/pkg/service/crud"
 )
 
-// ApplyFilters applies the filter group to the query
+// ApplyFilters applies the filter group to the query. The group is AND-ed with the other conditions of the query,
+// while its filters and nested groups are combined with the operator of the group.
 func ApplyFilters(query *bun.SelectQuery, filterGroup *crud.FilterGroup) {
-	if filterGroup == nil {
+	if filterGroup == nil || len(filterGroup.Filters) == 0 {
 		return
 	}
 
-	// Build the WHERE clause for the group
-	query.WhereGroup(string(filterGroup.Operator), func(q *bun.SelectQuery) *bun.SelectQuery {
-		for _, f := range filterGroup.Filters {
-			switch v := f.(type) {
-			case crud.Filter:
-				applyFilter(q, v)
-			case crud.FilterGroup:
-				ApplyFilters(q, &v)
-			default:
-				// Handle potential marshaling from map[string]interface{}
-				if marshaled, err := json.Marshal(f); err == nil {
-					var concreteFilter crud.Filter
-					if err := json.Unmarshal(marshaled, &concreteFilter); err == nil {
-						applyFilter(q, concreteFilter)
-						continue
-					}
+	query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return applyFilterGroup(q, filterGroup)
+	})
+}
+
+// applyFilterGroup adds the filters of a group to the query, combined with the operator of the group.
+func applyFilterGroup(q *bun.SelectQuery, filterGroup *crud.FilterGroup) *bun.SelectQuery {
+	where, sep := q.Where, " AND "
+	if filterGroup.Operator == crud.LogicalOr {
+		where, sep = q.WhereOr, " OR "
+	}
+
+	for _, f := range filterGroup.Filters {
+		switch v := f.(type) {
+		case crud.Filter:
+			applyFilter(where, v)
+		case crud.FilterGroup:
+			applyNestedFilterGroup(q, sep, &v)
+		case *crud.FilterGroup:
+			applyNestedFilterGroup(q, sep, v)
+		default:
+			// Handle potential marshaling from map[string]interface{}
+			if marshaled, err := json.Marshal(f); err == nil {
+				var concreteFilter crud.Filter
+				if err := json.Unmarshal(marshaled, &concreteFilter); err == nil && concreteFilter.Field != "" {
+					applyFilter(where, concreteFilter)
+					continue
+				}
 
-					var concreteGroup crud.FilterGroup
-					if err := json.Unmarshal(marshaled, &concreteGroup); err == nil {
-						ApplyFilters(q, &concreteGroup)
-						continue
-					}
+				var concreteGroup crud.FilterGroup
+				if err := json.Unmarshal(marshaled, &concreteGroup); err == nil {
+					applyNestedFilterGroup(q, sep, &concreteGroup)
+					continue
 				}
 			}
 		}
-		return q
+	}
+	return q
+}
+
+// applyNestedFilterGroup adds a group within another group, separated from its siblings by sep.
+func applyNestedFilterGroup(q *bun.SelectQuery, sep string, filterGroup *crud.FilterGroup) {
+	if filterGroup == nil || len(filterGroup.Filters) == 0 {
+		return
+	}
+
+	q.WhereGroup(sep, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return applyFilterGroup(q, filterGroup)
 	})
 }
 
-// applyFilter applies a single filter to the query
-func applyFilter(q *bun.SelectQuery, filter crud.Filter) {
+// applyFilter applies a single filter to the query through where, which is either Where or WhereOr
+func applyFilter(where func(query string, args ...interface{}) *bun.SelectQuery, filter crud.Filter) {
 	switch filter.Operator {
 	case crud.OperatorEqual:
-		q.Where("? = ?", bun.Ident(filter.Field), filter.Value)
+		where("? = ?", bun.Ident(filter.Field), filter.Value)
 	case crud.OperatorNotEqual:
-		q.Where("? != ?", bun.Ident(filter.Field), filter.Value)
+		where("? != ?", bun.Ident(filter.Field), filter.Value)
 	case crud.OperatorGreaterThan:
-		q.Where("? > ?", bun.Ident(filter.Field), filter.Value)
+		where("? > ?", bun.Ident(filter.Field), filter.Value)
 	case crud.OperatorGreaterThanOrEqual:
-		q.Where("? >= ?", bun.Ident(filter.Field), filter.Value)
+		where("? >= ?", bun.Ident(filter.Field), filter.Value)
 	case crud.OperatorLessThan:
-		q.Where("? < ?", bun.Ident(filter.Field), filter.Value)
+		where("? < ?", bun.Ident(filter.Field), filter.Value)
 	case crud.OperatorLessThanOrEqual:
-		q.Where("? <= ?", bun.Ident(filter.Field), filter.Value)
+		where("? <= ?", bun.Ident(filter.Field), filter.Value)
 	case crud.OperatorLike:
-		q.Where("? LIKE ?", bun.Ident(filter.Field), fmt.Sprintf("%%%v%%", filter.Value))
+		where("? LIKE ?", bun.Ident(filter.Field), fmt.Sprintf("%%%v%%", filter.Value))
 	case crud.OperatorILike:
-		q.Where("? ILIKE ?", bun.Ident(filter.Field), fmt.Sprintf("%%%v%%", filter.Value))
+		where("? ILIKE ?", bun.Ident(filter.Field), fmt.Sprintf("%%%v%%", filter.Value))
 	case crud.OperatorIn:
-		if values, ok := filter.Value.([]interface{}); ok {
-			q.Where("? IN (?)", bun.Ident(filter.Field), bun.In(values))
+		switch values := filter.Value.(type) {
+		case []interface{}:
+			where("? IN (?)", bun.Ident(filter.Field), bun.In(values))
+		case *bun.SelectQuery:
+			where("? IN (?)", bun.Ident(filter.Field), values)
 		}
 	case crud.OperatorNotIn:
-		if values, ok := filter.Value.([]interface{}); ok {
-			q.Where("? NOT IN (?)", bun.Ident(filter.Field), bun.In(values))
+		switch values := filter.Value.(type) {
+		case []interface{}:
+			where("? NOT IN (?)", bun.Ident(filter.Field), bun.In(values))
+		case *bun.SelectQuery:
+			where("? NOT IN (?)", bun.Ident(filter.Field), values)
 		}
 	case crud.OperatorIsNull:
-		q.Where("? IS NULL", bun.Ident(filter.Field))
+		where("? IS NULL", bun.Ident(filter.Field))
 	case crud.OperatorIsNotNull:
-		q.Where("? IS NOT NULL", bun.Ident(filter.Field))
+		where("? IS NOT NULL", bun.Ident(filter.Field))
 	}
 }
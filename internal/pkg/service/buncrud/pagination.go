@@ -6,9 +6,15 @@ import (
 	"gobase/internal/pkg/service/crud"
 )
 
-// ApplyPagination applies pagination to the query
+// ApplyPagination applies pagination to the query, skipping the rows of the offset when it is set
+// and the ones of the previous pages otherwise.
 func ApplyPagination(query *bun.SelectQuery, pagination *crud.Pagination) *bun.SelectQuery {
-	if pagination != nil && pagination.Page > 0 && pagination.PageSize > 0 {
+	if pagination == nil || pagination.PageSize <= 0 {
+		return query
+	}
+	if pagination.Offset != nil {
+		query.Limit(pagination.PageSize).Offset(max(*pagination.Offset, 0))
+	} else if pagination.Page > 0 {
 		query.Limit(pagination.PageSize).Offset((pagination.Page - 1) * pagination.PageSize)
 	}
 	return query
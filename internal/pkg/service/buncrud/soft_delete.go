@@ -0,0 +1,25 @@
+package buncrud
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// NotDeleted returns the condition keeping the rows of a model that are not soft deleted, for the raw SQL and the
+// table expressions bun does not add it to itself. alias is the alias of the table in the query.
+// Like bun, it compares a nullzero soft delete column to NULL, and any other one to the zero time.
+func NotDeleted[T any](db bun.IDB, alias string) schema.QueryWithArgs {
+	table := db.Dialect().Tables().Get(reflect.TypeFor[T]())
+	field := table.SoftDeleteField
+	if field == nil {
+		return bun.SafeQuery("TRUE")
+	}
+
+	if field.IsPtr || field.NullZero {
+		return bun.SafeQuery("?.? IS NULL", bun.Ident(alias), bun.Ident(field.Name))
+	}
+	return bun.SafeQuery("?.? = ?", bun.Ident(alias), bun.Ident(field.Name), time.Time{})
+}
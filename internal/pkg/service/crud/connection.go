@@ -0,0 +1,178 @@
+package crud
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultConnectionSize is the number of edges of a connection when neither first nor last is given.
+	DefaultConnectionSize = 20
+	// MaxConnectionSize is the maximum number of edges of a connection.
+	MaxConnectionSize = 100
+
+	cursorPrefix = "cursor:"
+)
+
+// ErrInvalidCursor is returned when a connection cursor was not created by EncodeCursor.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrInvalidConnectionArgs is returned when the arguments of a connection can not be paginated.
+var ErrInvalidConnectionArgs = errors.New("invalid connection arguments")
+
+// PageInfo is the pagination information of a Relay connection.
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// Edge is an item of a Relay connection with the cursor pointing at it.
+type Edge[T any] struct {
+	Cursor string `json:"cursor"`
+	Node   T      `json:"node"`
+}
+
+// Connection is a page of items following the Relay cursor connections specification.
+type Connection[T any] struct {
+	Edges    []*Edge[T] `json:"edges"`
+	PageInfo PageInfo   `json:"pageInfo"`
+}
+
+// ConnectionArgs are the Relay pagination arguments of a connection field.
+// Either first with after or last with before paginate it, the others are ignored.
+type ConnectionArgs struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// ConnectionWindow is the range of rows a connection is loaded from.
+type ConnectionWindow struct {
+	// Offset is the number of rows before the first edge.
+	Offset int
+	// Size is the maximum number of edges.
+	Size int
+	// Backward is true when the connection is paginated with last and before.
+	Backward bool
+}
+
+// EncodeCursor returns the opaque cursor of the row at the offset of an ordered result.
+func EncodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// DecodeCursor returns the offset of the row a cursor created by EncodeCursor points at.
+func DecodeCursor(cursor string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), cursorPrefix))
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return offset, nil
+}
+
+// Window converts the arguments to the range of rows to load. The size defaults to DefaultConnectionSize and is
+// capped at MaxConnectionSize. Cursors are offsets in the ordered result, so last needs before to count back from.
+func (a ConnectionArgs) Window() (ConnectionWindow, error) {
+	if a.First != nil && a.Last != nil {
+		return ConnectionWindow{}, fmt.Errorf("%w: first and last can not be used together", ErrInvalidConnectionArgs)
+	}
+
+	if a.Last != nil || (a.Before != nil && a.First == nil) {
+		size, err := connectionSize(a.Last)
+		if err != nil {
+			return ConnectionWindow{}, err
+		}
+		if a.Before == nil {
+			return ConnectionWindow{}, fmt.Errorf("%w: last needs before", ErrInvalidConnectionArgs)
+		}
+
+		before, err := DecodeCursor(*a.Before)
+		if err != nil {
+			return ConnectionWindow{}, err
+		}
+
+		offset := max(before-size, 0)
+		return ConnectionWindow{Offset: offset, Size: before - offset, Backward: true}, nil
+	}
+
+	size, err := connectionSize(a.First)
+	if err != nil {
+		return ConnectionWindow{}, err
+	}
+
+	offset := 0
+	if a.After != nil {
+		after, err := DecodeCursor(*a.After)
+		if err != nil {
+			return ConnectionWindow{}, err
+		}
+		offset = after + 1
+	}
+
+	return ConnectionWindow{Offset: offset, Size: size}, nil
+}
+
+// connectionSize returns the number of edges asked for by first or last.
+func connectionSize(size *int) (int, error) {
+	if size == nil {
+		return DefaultConnectionSize, nil
+	}
+	if *size < 0 {
+		return 0, fmt.Errorf("%w: the number of edges can not be negative", ErrInvalidConnectionArgs)
+	}
+	return min(*size, MaxConnectionSize), nil
+}
+
+// Pagination returns the pagination loading the rows of the window. It loads one more row than the size of the
+// window, so NewConnection can tell whether there is a next page.
+func (w ConnectionWindow) Pagination() *Pagination {
+	return &Pagination{
+		Page:     1,
+		PageSize: w.Size + 1,
+		Offset:   &w.Offset,
+	}
+}
+
+// NewConnection creates a connection from the rows loaded with the pagination of the window.
+func NewConnection[T any](items []T, window ConnectionWindow) *Connection[T] {
+	connection := &Connection[T]{
+		Edges: make([]*Edge[T], 0, len(items)),
+		PageInfo: PageInfo{
+			HasPreviousPage: window.Offset > 0,
+		},
+	}
+
+	// The row after a backward window is the one of the cursor it was counted back from.
+	if window.Backward || len(items) > window.Size {
+		connection.PageInfo.HasNextPage = true
+	}
+	if len(items) > window.Size {
+		items = items[:window.Size]
+	}
+
+	for i, item := range items {
+		connection.Edges = append(connection.Edges, &Edge[T]{
+			Cursor: EncodeCursor(window.Offset + i),
+			Node:   item,
+		})
+	}
+
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+
+	return connection
+}
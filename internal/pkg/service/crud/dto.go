@@ -50,6 +50,9 @@ type Pagination struct {
 	Page      int  `json:"page"`
 	PageSize  int  `json:"pageSize"`
 	WithCount bool `json:"withCount"`
+	// Offset, when set, is the number of rows skipped instead of the ones of the previous pages.
+	// It is used by the cursors of connections, which do not fall on page boundaries.
+	Offset *int `json:"offset,omitempty"`
 }
 
 // Sort defines the sorting parameters
@@ -58,7 +61,8 @@ type Sort struct {
 	Direction string `json:"direction"`
 }
 
-// Filter defines a single filter criterion
+// Filter defines a single filter criterion.
+// The value of the in and nin operators is either a slice or a subquery of the repository implementation.
 type Filter struct {
 	Field    string         `json:"field"`
 	Operator FilterOperator `json:"operator"`
@@ -78,6 +82,10 @@ type QueryOptions struct {
 	Filters    *FilterGroup `json:"filters,omitempty"`
 }
 
+// UnpagedPageSize is the size of the first page loaded for the query options of a client that give no page, so that
+// the list can not be loaded whole.
+const UnpagedPageSize = 100
+
 // NewQueryOptions creates a new QueryOptions with default pagination.
 func NewQueryOptions() *QueryOptions {
 	return &QueryOptions{
@@ -94,6 +102,26 @@ func (q *QueryOptions) WithPagination(page, pageSize int) *QueryOptions {
 	return q
 }
 
+// WithDefaultPagination sets the first page of UnpagedPageSize rows when the query has no page nor offset to load.
+func (q *QueryOptions) WithDefaultPagination() *QueryOptions {
+	if q.Pagination != nil && q.Pagination.PageSize > 0 && (q.Pagination.Page > 0 || q.Pagination.Offset != nil) {
+		return q
+	}
+
+	pagination := Pagination{}
+	if q.Pagination != nil {
+		pagination = *q.Pagination
+	}
+	if pagination.PageSize <= 0 {
+		pagination.PageSize = UnpagedPageSize
+	}
+	if pagination.Page <= 0 && pagination.Offset == nil {
+		pagination.Page = 1
+	}
+	q.Pagination = &pagination
+	return q
+}
+
 // WithSort adds a sort criterion to the query.
 func (q *QueryOptions) WithSort(field, direction string) *QueryOptions {
 	q.Sorts = append(q.Sorts, Sort{Field: field, Direction: direction})
@@ -105,3 +133,19 @@ func (q *QueryOptions) WithFilter(filter *FilterGroup) *QueryOptions {
 	q.Filters = filter
 	return q
 }
+
+// AndFilter adds the given filters to the query, combined with the existing filters using AND.
+func (q *QueryOptions) AndFilter(filters ...any) *QueryOptions {
+	if len(filters) == 0 {
+		return q
+	}
+
+	if q.Filters == nil {
+		q.Filters = &FilterGroup{Operator: LogicalAnd}
+	} else if q.Filters.Operator != LogicalAnd {
+		q.Filters = &FilterGroup{Operator: LogicalAnd, Filters: []any{*q.Filters}}
+	}
+
+	q.Filters.Filters = append(q.Filters.Filters, filters...)
+	return q
+}
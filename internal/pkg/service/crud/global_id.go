@@ -0,0 +1,36 @@
+package crud
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidGlobalID is returned when a global id was not created by ToGlobalID.
+var ErrInvalidGlobalID = errors.New("invalid global id")
+
+// Node is an object that can be refetched by its globally unique id, following the Relay global object
+// identification specification.
+type Node interface {
+	GlobalID() string
+}
+
+// ToGlobalID returns the opaque, globally unique id of the object of a type with an id.
+func ToGlobalID(typeName string, id string) string {
+	return base64.StdEncoding.EncodeToString([]byte(typeName + ":" + id))
+}
+
+// FromGlobalID returns the type and the id of the object of a global id created by ToGlobalID.
+func FromGlobalID(globalId string) (typeName string, id string, err error) {
+	decoded, err := base64.StdEncoding.DecodeString(globalId)
+	if err != nil {
+		return "", "", ErrInvalidGlobalID
+	}
+
+	typeName, id, ok := strings.Cut(string(decoded), ":")
+	if !ok || typeName == "" || id == "" {
+		return "", "", ErrInvalidGlobalID
+	}
+
+	return typeName, id, nil
+}
@@ -2,14 +2,38 @@ package gqldataloader
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/graph-gophers/dataloader/v7"
 	"github.com/samber/lo"
 
 	"gobase/internal/pkg/service/buncrud"
+	"gobase/internal/pkg/service/crud"
 )
 
+// Options configures the batching of the loaders created by NewLoader.
+type Options struct {
+	// Wait is how long a loader collects keys before loading them in a batch. Zero keeps the dataloader default.
+	Wait time.Duration
+	// BatchCapacity is the maximum number of keys loaded in a batch. Zero means unlimited.
+	BatchCapacity int
+}
+
+// NewLoader creates a loader batching with the given options. A loader caches what it loaded for as long as it lives,
+// so loaders are meant to be created per request.
+func NewLoader[K comparable, V any](batchFn dataloader.BatchFunc[K, V], opts Options) *dataloader.Loader[K, V] {
+	var loaderOpts []dataloader.Option[K, V]
+	if opts.Wait > 0 {
+		loaderOpts = append(loaderOpts, dataloader.WithWait[K, V](opts.Wait))
+	}
+	if opts.BatchCapacity > 0 {
+		loaderOpts = append(loaderOpts, dataloader.WithBatchCapacity[K, V](opts.BatchCapacity))
+	}
+	return dataloader.NewBatchedLoader(batchFn, loaderOpts...)
+}
+
 // NewGenericBatchFn creates a generic batch function for a dataloader.
 // K is the key type (e.g., uuid.UUID).
 // V is the entity type (e.g., masterdataentity.ProductVariant).
@@ -29,29 +53,8 @@ func NewGenericBatchFn[K comparable, V any, T any](
 			results[i] = &dataloader.Result[[]*T]{Data: make([]*T, 0)}
 		}
 
-		var anyKeys []any
-		if len(columns) > 1 && getKeyValues != nil {
-			// For composite keys, map each key struct to a slice of its values.
-			anyKeys = lo.Map(keys, func(key K, _ int) any {
-				return getKeyValues(key) // bun expects a slice of slices for composite IN
-			})
-		} else {
-			// For single keys, map each key to its value directly.
-			anyKeys = lo.Map(keys, func(key K, _ int) any {
-				return key
-			})
-		}
-
-		var items []*V // Changed back to slice of pointers
-		var err error
-
 		// Fetch all items for the given keys.
-		if len(columns) == 1 {
-			items, err = repo.FindIn(ctx, columns[0], anyKeys, nil)
-		} else {
-			items, err = repo.FindIn(ctx, "("+strings.Join(columns, ", ")+")", anyKeys, nil)
-		}
-
+		items, err := findItems(ctx, repo, columns, getKeyValues, keys)
 		if err != nil {
 			for i := range keys {
 				results[i] = &dataloader.Result[[]*T]{Error: err}
@@ -78,3 +81,186 @@ func NewGenericBatchFn[K comparable, V any, T any](
 		return results
 	}
 }
+
+// NotFoundError is the error of a key without an item, for loaders created by NewGenericOneBatchFn with NotFound.
+// It matches crud.ErrNotFound with errors.Is.
+type NotFoundError[K comparable] struct {
+	Key K
+}
+
+func (e *NotFoundError[K]) Error() string {
+	return fmt.Sprintf("%v: %v", crud.ErrNotFound, e.Key)
+}
+
+func (e *NotFoundError[K]) Is(target error) bool {
+	return target == crud.ErrNotFound
+}
+
+// NotFound returns a NotFoundError for the key. It can be given to NewGenericOneBatchFn as its notFound function.
+func NotFound[K comparable](key K) error {
+	return &NotFoundError[K]{Key: key}
+}
+
+// NewGenericOneBatchFn creates a generic batch function for a dataloader of belongs-to relations, which load at most
+// one item per key. A key without an item resolves to nil, or to the error of notFound when it is not nil.
+// The type parameters and the other arguments are the same as the ones of NewGenericBatchFn.
+func NewGenericOneBatchFn[K comparable, V any, T any](
+	repo buncrud.BaseRepository[V],
+	columns []string,
+	getKey func(item *V) K,
+	getKeyValues func(key K) []any,
+	transform func(item *V) *T,
+	notFound func(key K) error,
+) dataloader.BatchFunc[K, *T] {
+	return func(ctx context.Context, keys []K) []*dataloader.Result[*T] {
+		results := make([]*dataloader.Result[*T], len(keys))
+
+		items, err := findItems(ctx, repo, columns, getKeyValues, keys)
+		if err != nil {
+			for i := range keys {
+				results[i] = &dataloader.Result[*T]{Error: err}
+			}
+			return results
+		}
+
+		// The first item of a key wins, should the columns not be unique.
+		itemsByKey := make(map[K]*V, len(items))
+		for _, item := range items {
+			key := getKey(item)
+			if _, ok := itemsByKey[key]; !ok {
+				itemsByKey[key] = item
+			}
+		}
+
+		for i, key := range keys {
+			item, ok := itemsByKey[key]
+			switch {
+			case ok:
+				results[i] = &dataloader.Result[*T]{Data: transform(item)}
+			case notFound != nil:
+				results[i] = &dataloader.Result[*T]{Error: notFound(key)}
+			default:
+				results[i] = &dataloader.Result[*T]{}
+			}
+		}
+
+		return results
+	}
+}
+
+// PageKey identifies the first items of a parent for a set of arguments, e.g. sorts and filters. Args is a canonical
+// encoding of the arguments such as their JSON, so that the keys of equal arguments are equal while the keys of
+// different arguments do not collide.
+type PageKey[K comparable] struct {
+	ParentID K
+	// First is the number of items to load. Zero loads all of them.
+	First int
+	Args  string
+}
+
+// pageGroup holds the parameters of a PageKey that are shared by the keys loaded in one query.
+type pageGroup struct {
+	First int
+	Args  string
+}
+
+// NewGenericPageBatchFn creates a generic batch function for a dataloader of has-many relations that loads the first
+// items of each parent, e.g. the 3 cheapest variants of each product. Keys of equal arguments are loaded in one query,
+// with the query options returned by toQueryOptions for the arguments.
+// The type parameters and the other arguments are the same as the ones of NewGenericBatchFn.
+func NewGenericPageBatchFn[K comparable, V any, T any](
+	repo buncrud.BaseRepository[V],
+	column string,
+	getKey func(item *V) K,
+	toQueryOptions func(args string) (*crud.QueryOptions, error),
+	transform func(item *V) *T,
+) dataloader.BatchFunc[PageKey[K], []*T] {
+	return NewGroupedBatchFn(
+		func(key PageKey[K]) pageGroup {
+			return pageGroup{First: key.First, Args: key.Args}
+		},
+		func(ctx context.Context, group pageGroup, keys []PageKey[K]) (map[PageKey[K]][]*T, error) {
+			options, err := toQueryOptions(group.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			parentIds := lo.Map(keys, func(key PageKey[K], _ int) any {
+				return key.ParentID
+			})
+
+			items, err := repo.FindTopIn(ctx, column, parentIds, group.First, options)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[PageKey[K]][]*T, len(keys))
+			for _, key := range keys {
+				result[key] = make([]*T, 0)
+			}
+			for _, item := range items {
+				key := PageKey[K]{ParentID: getKey(item), First: group.First, Args: group.Args}
+				result[key] = append(result[key], transform(item))
+			}
+
+			return result, nil
+		},
+	)
+}
+
+// findItems returns all the items whose columns match one of the keys.
+func findItems[K comparable, V any](
+	ctx context.Context,
+	repo buncrud.BaseRepository[V],
+	columns []string,
+	getKeyValues func(key K) []any,
+	keys []K,
+) ([]*V, error) {
+	var anyKeys []any
+	if len(columns) > 1 && getKeyValues != nil {
+		// For composite keys, map each key struct to a slice of its values.
+		anyKeys = lo.Map(keys, func(key K, _ int) any {
+			return getKeyValues(key) // bun expects a slice of slices for composite IN
+		})
+	} else {
+		// For single keys, map each key to its value directly.
+		anyKeys = lo.Map(keys, func(key K, _ int) any {
+			return key
+		})
+	}
+
+	// Empty query options, since the default ones would only return the first page of the items.
+	if len(columns) == 1 {
+		return repo.FindIn(ctx, columns[0], anyKeys, &crud.QueryOptions{})
+	}
+	return repo.FindIn(ctx, "("+strings.Join(columns, ", ")+")", anyKeys, &crud.QueryOptions{})
+}
+
+// NewGroupedBatchFn creates a batch function for keys that carry query parameters besides an id, e.g. a limit.
+// Keys are grouped by the parameters returned by group, and fetch is called once per group with its keys.
+// Keys missing from the result of fetch resolve to the zero value of V.
+func NewGroupedBatchFn[K comparable, G comparable, V any](
+	group func(key K) G,
+	fetch func(ctx context.Context, group G, keys []K) (map[K]V, error),
+) dataloader.BatchFunc[K, V] {
+	return func(ctx context.Context, keys []K) []*dataloader.Result[V] {
+		results := make([]*dataloader.Result[V], len(keys))
+
+		for g, groupKeys := range lo.GroupBy(lo.Uniq(keys), group) {
+			values, err := fetch(ctx, g, groupKeys)
+
+			for i, key := range keys {
+				if group(key) != g {
+					continue
+				}
+				if err != nil {
+					results[i] = &dataloader.Result[V]{Error: err}
+					continue
+				}
+				results[i] = &dataloader.Result[V]{Data: values[key]}
+			}
+		}
+
+		return results
+	}
+}
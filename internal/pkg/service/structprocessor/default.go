@@ -2,79 +2,144 @@ package structprocessor
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	validator "github.com/go-playground/validator/v10"
+
+	"gobase/internal/pkg/helper"
 )
 
 func (m *StructProcessorServiceModule) DefaultTagValidationErrorHandler(ctx context.Context, languageId string, validationErrors validator.ValidationErrors) error {
 	if len(validationErrors) > 0 {
 		for _, r := range validationErrors {
-			fieldName := m.localizer.Localize(languageId, r.Field(), nil)
+			fieldName := helper.MessageParam(r.Field())
 			if r.Tag() == "required" {
-				return errors.New(m.localizer.Localize(
+				return helper.NewLocalizedErr(
+					m.localizer,
 					languageId,
+					helper.ErrorKindBadRequest,
 					"ErrorFieldRequired",
 					map[string]interface{}{
 						"FieldName": fieldName,
 					},
-				))
+				)
 			}
 			if r.Tag() == "number" {
-				return errors.New(m.localizer.Localize(
+				return helper.NewLocalizedErr(
+					m.localizer,
 					languageId,
+					helper.ErrorKindBadRequest,
 					"ErrorStringNotValidNumber",
 					map[string]interface{}{
 						"StringName": fieldName,
 					},
-				))
+				)
 			}
 			if r.Tag() == "min" {
-				return errors.New(m.localizer.Localize(
+				return helper.NewLocalizedErr(
+					m.localizer,
 					languageId,
+					helper.ErrorKindBadRequest,
 					"ErrorMinStringLength",
 					map[string]interface{}{
 						"StringName": fieldName,
 						"MinLength":  r.Param(),
 					},
-				))
+				)
 			}
 			if r.Tag() == "max" {
-				return errors.New(m.localizer.Localize(
+				return helper.NewLocalizedErr(
+					m.localizer,
 					languageId,
+					helper.ErrorKindBadRequest,
 					"ErrorMaxStringLength",
 					map[string]interface{}{
 						"StringName": fieldName,
 						"MaxLength":  r.Param(),
 					},
-				))
+				)
+			}
+			if r.Tag() == "gte" {
+				return helper.NewLocalizedErr(
+					m.localizer,
+					languageId,
+					helper.ErrorKindBadRequest,
+					"ErrorMinValue",
+					map[string]interface{}{
+						"FieldName": fieldName,
+						"MinValue":  r.Param(),
+					},
+				)
+			}
+			if r.Tag() == "lte" {
+				return helper.NewLocalizedErr(
+					m.localizer,
+					languageId,
+					helper.ErrorKindBadRequest,
+					"ErrorMaxValue",
+					map[string]interface{}{
+						"FieldName": fieldName,
+						"MaxValue":  r.Param(),
+					},
+				)
+			}
+			if r.Tag() == "oneof" {
+				return helper.NewLocalizedErr(
+					m.localizer,
+					languageId,
+					helper.ErrorKindBadRequest,
+					"ErrorInvalidOption",
+					map[string]interface{}{
+						"FieldName": fieldName,
+						"Options":   r.Param(),
+					},
+				)
+			}
+			if r.Tag() == "iso4217" {
+				return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorInvalidCurrency", nil)
+			}
+			if r.Tag() == "language" {
+				return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorUnsupportedLanguage", nil)
 			}
 			if r.Tag() == "email" {
-				return errors.New(m.localizer.Localize(languageId, "ErrorInvalidEmail", nil))
+				return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorInvalidEmail", nil)
 			}
 			if r.Tag() == "phone" {
-				return errors.New(m.localizer.Localize(languageId, "ErrorInvalidPhoneNumber", nil))
+				return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorInvalidPhoneNumber", nil)
 			}
 			if r.Tag() == "longitude" {
-				return errors.New(m.localizer.Localize(languageId, "ErrorInvalidLongLat", nil))
+				return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorInvalidLongLat", nil)
 			}
 			if r.Tag() == "latitude" {
-				return errors.New(m.localizer.Localize(languageId, "ErrorInvalidLongLat", nil))
+				return helper.NewLocalizedErr(m.localizer, languageId, helper.ErrorKindBadRequest, "ErrorInvalidLongLat", nil)
+			}
+			if r.Tag() == "gtfield" {
+				return helper.NewLocalizedErr(
+					m.localizer,
+					languageId,
+					helper.ErrorKindBadRequest,
+					"ErrorFieldNotAfter",
+					map[string]interface{}{
+						"FieldName":  fieldName,
+						"AfterField": helper.MessageParam(r.Param()),
+					},
+				)
 			}
 			if r.Tag() == "eqfield" {
-				return errors.New(m.localizer.Localize(
+				return helper.NewLocalizedErr(
+					m.localizer,
 					languageId,
+					helper.ErrorKindBadRequest,
 					"ErrorFieldNotEqual",
 					map[string]interface{}{
 						"FieldName":    fieldName,
 						"EqualToField": r.Param(),
 					},
-				))
+				)
 			}
 		}
 
-		return fmt.Errorf("tag validation failed. field name: %s. tag arg:%s", validationErrors[0].Field(), validationErrors[0].Tag())
+		return fmt.Errorf("tag validation failed. field name: %s. tag arg:%s: %w", validationErrors[0].Field(), validationErrors[0].Tag(), validationErrors)
 	}
 
 	return nil
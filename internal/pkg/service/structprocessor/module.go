@@ -4,11 +4,16 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"sync"
 
 	"clodeo.tech/public/go-universe/pkg/localization"
 	pkgTagTransform "clodeo.tech/public/go-universe/pkg/tag/component/transform"
 	validator "github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"gobase/internal/pkg/helper/language"
+	"gobase/internal/pkg/helper/money"
 )
 
 type TagValidationErrorHandler = func(ctx context.Context, languageId string, validationErrors validator.ValidationErrors) error
@@ -16,7 +21,11 @@ type TagValidationErrorHandler = func(ctx context.Context, languageId string, va
 type StructProcessorService interface {
 	TransformByTag(obj interface{}) error
 	ValidateByTag(ctx context.Context, obj interface{}) error
+	// TransformAndValidateByTag transforms and validates obj by its tags, then runs the rules registered for its type.
 	TransformAndValidateByTag(ctx context.Context, obj interface{}) error
+	// RegisterRules adds rules that run after the tag validation of objects of the type of obj.
+	RegisterRules(obj interface{}, rules ...Rule)
+	ValidateByRule(ctx context.Context, obj interface{}) error
 }
 
 type StructProcessorServiceModule struct {
@@ -24,6 +33,8 @@ type StructProcessorServiceModule struct {
 	validatorValidate      *validator.Validate
 	validationErrorHandler TagValidationErrorHandler
 	transformFunc          func(obj interface{}) error
+	rules                  map[reflect.Type][]Rule
+	rulesMu                sync.RWMutex
 }
 
 type StructProcessorServiceModuleOpts struct {
@@ -48,11 +59,28 @@ func NewStructProcessorService(opts StructProcessorServiceModuleOpts) StructProc
 		return nil
 	}, uuid.UUID{})
 
+	// Decimals are compared as floats, so numeric tags like gte work on money amounts.
+	opts.ValidatorValidate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if d, ok := field.Interface().(decimal.Decimal); ok {
+			return d.InexactFloat64()
+		}
+		return nil
+	}, decimal.Decimal{})
+
+	_ = opts.ValidatorValidate.RegisterValidation("iso4217", func(fl validator.FieldLevel) bool {
+		return money.IsValidCurrency(fl.Field().String())
+	})
+
+	_ = opts.ValidatorValidate.RegisterValidation("language", func(fl validator.FieldLevel) bool {
+		return language.IsSupported(fl.Field().String())
+	})
+
 	return &StructProcessorServiceModule{
 		localizer:              opts.Localizer,
 		transformFunc:          opts.TransformFunc,
 		validatorValidate:      opts.ValidatorValidate,
 		validationErrorHandler: opts.ValidationErrorHandler,
+		rules:                  make(map[reflect.Type][]Rule),
 	}
 }
 
@@ -96,5 +124,10 @@ func (m *StructProcessorServiceModule) TransformAndValidateByTag(ctx context.Con
 		return err
 	}
 
+	err = m.ValidateByRule(ctx, obj)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
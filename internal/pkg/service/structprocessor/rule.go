@@ -0,0 +1,62 @@
+package structprocessor
+
+import (
+	"context"
+	"reflect"
+
+	"gobase/internal/pkg/helper/language"
+)
+
+// Rule validates an object beyond its tags, e.g. by comparing its fields or by looking up the database.
+// It returns an error localized in the given language when the object is not valid.
+type Rule = func(ctx context.Context, languageId string, obj interface{}) error
+
+// RuleFor adapts a rule on a struct type to a Rule. Objects of another type are left alone.
+func RuleFor[T any](rule func(ctx context.Context, languageId string, obj *T) error) Rule {
+	return func(ctx context.Context, languageId string, obj interface{}) error {
+		switch v := obj.(type) {
+		case *T:
+			return rule(ctx, languageId, v)
+		case T:
+			return rule(ctx, languageId, &v)
+		}
+		return nil
+	}
+}
+
+// RegisterRules adds rules for the type of obj, which can be given as a value or a pointer.
+// Rules run in the order they are registered.
+func (m *StructProcessorServiceModule) RegisterRules(obj interface{}, rules ...Rule) {
+	m.rulesMu.Lock()
+	defer m.rulesMu.Unlock()
+
+	t := ruleType(obj)
+	m.rules[t] = append(m.rules[t], rules...)
+}
+
+// ValidateByRule runs the rules registered for the type of obj and returns the error of the first failing one,
+// localized in the language of the request.
+func (m *StructProcessorServiceModule) ValidateByRule(ctx context.Context, obj interface{}) error {
+	langId := language.FromContext(ctx)
+
+	m.rulesMu.RLock()
+	rules := m.rules[ruleType(obj)]
+	m.rulesMu.RUnlock()
+
+	for _, rule := range rules {
+		if err := rule(ctx, langId, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ruleType returns the type rules are registered under, which is the struct type behind any pointers.
+func ruleType(obj interface{}) reflect.Type {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
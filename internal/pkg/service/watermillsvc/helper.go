@@ -1,10 +1,13 @@
 package watermillsvc
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
+
+	"gobase/internal/pkg/service/authsvc"
 )
 
 func BuildMessage(messageId uuid.UUID, payload interface{}) (*message.Message, error) {
@@ -19,7 +22,9 @@ func BuildMessage(messageId uuid.UUID, payload interface{}) (*message.Message, e
 	return message.NewMessage(messageId.String(), payloadBytes), nil
 }
 
-func BuildNewMessage(payload interface{}) (*message.Message, error) {
+// BuildNewMessage builds a message with a new id. The principal of ctx, if any, is put in its metadata so that the
+// subscribers know who caused the event.
+func BuildNewMessage(ctx context.Context, payload interface{}) (*message.Message, error) {
 	var payloadBytes []byte
 	var err error
 	if payload != nil {
@@ -28,5 +33,21 @@ func BuildNewMessage(payload interface{}) (*message.Message, error) {
 			return nil, err
 		}
 	}
-	return message.NewMessage(uuid.New().String(), payloadBytes), nil
+	msg := message.NewMessage(uuid.New().String(), payloadBytes)
+	if principal, ok := authsvc.FromContext(ctx); ok {
+		for key, value := range principal.Metadata() {
+			msg.Metadata.Set(key, value)
+		}
+	}
+	return msg, nil
+}
+
+// PrincipalMiddleware puts the principal of the metadata of a message, if any, in the context of the message.
+func PrincipalMiddleware(h message.HandlerFunc) message.HandlerFunc {
+	return func(msg *message.Message) ([]*message.Message, error) {
+		if principal, ok := authsvc.FromMetadata(msg.Metadata); ok {
+			msg.SetContext(authsvc.WithPrincipal(msg.Context(), principal))
+		}
+		return h(msg)
+	}
 }
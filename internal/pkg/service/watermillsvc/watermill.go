@@ -63,6 +63,7 @@ func NewService(opts ServiceOpts) (Service, error) {
 			Logger:          opts.Logger,
 		}.Middleware,
 		middleware.Recoverer,
+		PrincipalMiddleware,
 	)
 
 	svc := &ServiceModule{
@@ -0,0 +1,97 @@
+package transportgraphql
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+
+	graphqlgen "gobase/graphql/generated"
+	categorydto "gobase/internal/domain/category/dto"
+	inventorydto "gobase/internal/domain/inventory/dto"
+	productloader "gobase/internal/domain/product/dataloader"
+	productdto "gobase/internal/domain/product/dto"
+	productusecase "gobase/internal/domain/product/usecase"
+	"gobase/internal/pkg/service/crud"
+)
+
+// descendantsListSize is the assumed number of the descendants of a category, which are loaded without a limit.
+const descendantsListSize = 100
+
+// newComplexityRoot returns the complexity functions of the list fields, which cost the cost of their items times
+// the number of items they can load as given by their pageSize, first or limit argument.
+// The other fields cost 1 plus the cost of their selection.
+func newComplexityRoot() graphqlgen.ComplexityRoot {
+	var root graphqlgen.ComplexityRoot
+
+	root.Query.Products = func(childComplexity int, qop *productdto.ProductQop, lang *string, search *string) int {
+		return listComplexity(childComplexity, qopPageSize(qop, func(qop *productdto.ProductQop) *crud.QueryOptions {
+			return &qop.QueryOptions
+		}))
+	}
+	root.Query.ProductsConnection = func(childComplexity int, first *int, after *string, last *int, before *string, filters *productdto.ProductQopFilter, sorts []*crud.Sort, lang *string, search *string) int {
+		size := first
+		if size == nil {
+			size = last
+		}
+		return listComplexity(childComplexity, limitSize(size, crud.DefaultConnectionSize, crud.MaxConnectionSize))
+	}
+	root.Query.ProductBundles = func(childComplexity int, qop *productdto.ProductBundleQop) int {
+		return listComplexity(childComplexity, qopPageSize(qop, func(qop *productdto.ProductBundleQop) *crud.QueryOptions {
+			return &qop.QueryOptions
+		}))
+	}
+	root.Query.ProductTags = func(childComplexity int, prefix *string, limit *int) int {
+		return listComplexity(childComplexity, limitSize(limit, productusecase.DefaultTagSuggestionLimit, productusecase.MaxTagSuggestionLimit))
+	}
+	root.Query.Categories = func(childComplexity int, qop *categorydto.CategoryQop) int {
+		return listComplexity(childComplexity, qopPageSize(qop, func(qop *categorydto.CategoryQop) *crud.QueryOptions {
+			return &qop.QueryOptions
+		}))
+	}
+	root.Query.CategoryDescendants = func(childComplexity int, id uuid.UUID, maxDepth *int) int {
+		return listComplexity(childComplexity, descendantsListSize)
+	}
+	root.Query.InventoryMovements = func(childComplexity int, qop *inventorydto.InventoryMovementQop) int {
+		return listComplexity(childComplexity, qopPageSize(qop, func(qop *inventorydto.InventoryMovementQop) *crud.QueryOptions {
+			return &qop.QueryOptions
+		}))
+	}
+
+	root.Product.Variants = func(childComplexity int, first *int, sort []*crud.Sort, filter *productdto.ProductVariantQopFilter) int {
+		return listComplexity(childComplexity, limitSize(first, productloader.MaxVariantsFirst, productloader.MaxVariantsFirst))
+	}
+	root.Product.RelatedProducts = func(childComplexity int, limit *int, categoryWeight *float64, tagWeight *float64) int {
+		return listComplexity(childComplexity, limitSize(limit, productloader.DefaultRelatedProductsLimit, productloader.MaxRelatedProductsLimit))
+	}
+
+	return root
+}
+
+// listComplexity returns the complexity of a list of size items costing childComplexity each.
+// It saturates instead of overflowing, so a huge size can not wrap around under the limit.
+func listComplexity(childComplexity int, size int) int {
+	size = max(size, 1)
+	if childComplexity > 0 && size > (math.MaxInt-1)/childComplexity {
+		return math.MaxInt
+	}
+	return 1 + size*childComplexity
+}
+
+// limitSize returns the number of items loaded for a first or limit argument with a default and a maximum.
+func limitSize(limit *int, defaultSize int, maxSize int) int {
+	if limit == nil || *limit <= 0 {
+		return defaultSize
+	}
+	return min(*limit, maxSize)
+}
+
+// qopPageSize returns the number of items loaded for the query options of a qop. A nil qop loads the default page of
+// crud.NewQueryOptions, while a qop without a page loads the default page of its ToQueryOptions.
+func qopPageSize[Q any](qop *Q, queryOptions func(qop *Q) *crud.QueryOptions) int {
+	if qop == nil {
+		return crud.NewQueryOptions().Pagination.PageSize
+	}
+
+	options := *queryOptions(qop)
+	return options.WithDefaultPagination().Pagination.PageSize
+}
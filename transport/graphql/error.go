@@ -0,0 +1,166 @@
+package transportgraphql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	pkgErr "clodeo.tech/public/go-universe/pkg/err"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	validator "github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"gobase/internal/pkg/helper"
+	"gobase/internal/pkg/helper/language"
+	middlewaregraphql "gobase/internal/pkg/middleware/graphql"
+	"gobase/internal/pkg/service/authsvc"
+	"gobase/internal/pkg/service/crud"
+)
+
+const (
+	// ErrNotFound is the error code of the records that do not exist.
+	ErrNotFound = "NOT_FOUND"
+	// ErrBadUserInput is the error code of the invalid arguments and inputs.
+	ErrBadUserInput = "BAD_USER_INPUT"
+	// ErrConflict is the error code of the changes conflicting with the current state of a record.
+	ErrConflict = "CONFLICT"
+	// ErrUnauthenticated is the error code of the requests whose credentials are not valid.
+	ErrUnauthenticated = middlewaregraphql.ErrUnauthenticated
+	// ErrForbidden is the error code of the operations the caller is not allowed to run.
+	ErrForbidden = "FORBIDDEN"
+	// ErrInternal is the error code of the unexpected errors, whose details are hidden from clients
+	// unless ExposeInternalErrors is set.
+	ErrInternal = "INTERNAL"
+
+	traceIdExtension = "traceId"
+
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// errPanic is presented for the resolvers that panicked, once the panic is logged.
+var errPanic = errors.New("internal system error")
+
+// presentError gives the errors reaching clients an extensions.code, a message in the language of the request and
+// the trace id of the request. Errors that already have a code, such as the validation errors of gqlgen and the
+// limit errors, keep their code and message.
+func (m *TransportModule) presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	if _, ok := gqlErr.Extensions["code"]; !ok {
+		code, message := m.classifyError(ctx, err)
+		gqlErr.Message = message
+		errcode.Set(gqlErr, code)
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+		gqlErr.Extensions[traceIdExtension] = spanContext.TraceID().String()
+	}
+
+	return gqlErr
+}
+
+// classifyError returns the code and the message of an error without a code.
+func (m *TransportModule) classifyError(ctx context.Context, err error) (code string, message string) {
+	lang := language.FromContext(ctx)
+
+	var localizedErr *helper.LocalizedErr
+	var validationErrs validator.ValidationErrors
+	var pgErr *pgconn.PgError
+	var gqlErr *gqlerror.Error
+
+	switch {
+	case errors.As(err, &localizedErr):
+		return localizedErrorCode(localizedErr.Kind), localizedErr.Localize(m.localizer, lang)
+	case errors.Is(err, authsvc.ErrUnauthenticated):
+		return ErrUnauthenticated, m.localizer.Localize(lang, "ErrorUnauthenticated", nil)
+	case errors.Is(err, crud.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return ErrNotFound, m.localizer.Localize(lang, "ErrorRecordNotFound", nil)
+	case errors.Is(err, crud.ErrInvalidCursor):
+		return ErrBadUserInput, m.localizer.Localize(lang, "ErrorInvalidCursor", nil)
+	case errors.Is(err, crud.ErrInvalidConnectionArgs):
+		return ErrBadUserInput, m.localizer.Localize(lang, "ErrorInvalidConnectionArgs", nil)
+	case errors.Is(err, crud.ErrInvalidGlobalID):
+		return ErrBadUserInput, m.localizer.Localize(lang, "ErrorInvalidGlobalId", nil)
+	case errors.As(err, &validationErrs):
+		return ErrBadUserInput, m.localizer.Localize(lang, "ErrorInvalidInput", nil)
+	case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+		return ErrConflict, m.localizer.Localize(lang, "ErrorRecordAlreadyExists", nil)
+	case errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation:
+		return ErrBadUserInput, m.localizer.Localize(lang, "ErrorReferencedRecordNotFound", nil)
+	case errors.As(err, &gqlErr):
+		// gqlgen wraps the errors of the arguments and inputs it failed to unmarshal, e.g. a malformed UUID.
+		return ErrBadUserInput, gqlErr.Message
+	}
+
+	cusErr := pkgErr.GetError(err)
+	if code := httpErrorCode(cusErr.HTTPCode); code != ErrInternal && cusErr.Message != "" {
+		return code, cusErr.Message
+	}
+
+	if m.config.Server.GraphQL.ExposeInternalErrors {
+		return ErrInternal, err.Error()
+	}
+	return ErrInternal, m.localizer.Localize(lang, "ErrorInternal", nil)
+}
+
+// recoverPanic logs the panic of a resolver with its stack and records it on the span of the request.
+func (m *TransportModule) recoverPanic(ctx context.Context, err any) error {
+	event := log.Error().Interface("panic", err).Bytes("stack", debug.Stack())
+	if principal, ok := authsvc.FromContext(ctx); ok {
+		event = event.EmbedObject(principal)
+	}
+	event.Msg("graphql resolver panicked")
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(fmt.Errorf("panic: %v", err))
+	span.SetStatus(codes.Error, errPanic.Error())
+
+	if m.config.Server.GraphQL.ExposeInternalErrors {
+		return fmt.Errorf("%w: %v", errPanic, err)
+	}
+	return errPanic
+}
+
+func localizedErrorCode(kind helper.ErrorKind) string {
+	switch kind {
+	case helper.ErrorKindBadRequest:
+		return ErrBadUserInput
+	case helper.ErrorKindNotFound:
+		return ErrNotFound
+	case helper.ErrorKindConflict:
+		return ErrConflict
+	case helper.ErrorKindForbidden:
+		return ErrForbidden
+	default:
+		return ErrInternal
+	}
+}
+
+func httpErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrBadUserInput
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnauthorized:
+		return ErrUnauthenticated
+	case http.StatusForbidden:
+		return ErrForbidden
+	default:
+		return ErrInternal
+	}
+}
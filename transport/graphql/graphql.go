@@ -4,12 +4,15 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
+	"clodeo.tech/public/go-universe/pkg/localization"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gorilla/websocket"
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"gobase/config"
@@ -23,6 +26,12 @@ type TransportModule struct {
 	graphQLResolver      registry.GraphQLResolver
 	middlewareDataloader middlewaregraphql.Dataloader
 	middlewareOtel       middlewaregraphql.Otel
+	middlewareLanguage   middlewaregraphql.Language
+	middlewareLimit      middlewaregraphql.Limit
+	middlewareAuth       middlewaregraphql.Authentication
+	websocketInit        middlewaregraphql.WebsocketInit
+	trustedDocuments     *middlewaregraphql.TrustedDocuments
+	localizer            localization.Localizer
 	config               *config.MainConfig
 }
 
@@ -30,16 +39,32 @@ type TransportOpts struct {
 	GraphQLResolver      registry.GraphQLResolver
 	MiddlewareDataloader middlewaregraphql.Dataloader
 	MiddlewareOtel       middlewaregraphql.Otel
+	MiddlewareLanguage   middlewaregraphql.Language
+	MiddlewareLimit      middlewaregraphql.Limit
+	MiddlewareAuth       middlewaregraphql.Authentication
+	WebsocketInit        middlewaregraphql.WebsocketInit
+	TrustedDocuments     *middlewaregraphql.TrustedDocuments
+	Localizer            localization.Localizer
 	Config               *config.MainConfig
 }
 
-const defaultPort = "8181"
+const (
+	defaultPort = "8181"
+
+	websocketKeepAliveInterval = 10 * time.Second
+)
 
 func NewTransport(opts TransportOpts) (registry.IApplicationTransportGraphQL, registry.CleanupFunc) {
 	transportModule := &TransportModule{
 		graphQLResolver:      opts.GraphQLResolver,
 		middlewareDataloader: opts.MiddlewareDataloader,
 		middlewareOtel:       opts.MiddlewareOtel,
+		middlewareLanguage:   opts.MiddlewareLanguage,
+		middlewareLimit:      opts.MiddlewareLimit,
+		middlewareAuth:       opts.MiddlewareAuth,
+		websocketInit:        opts.WebsocketInit,
+		trustedDocuments:     opts.TrustedDocuments,
+		localizer:            opts.Localizer,
 		config:               opts.Config,
 	}
 
@@ -56,25 +81,42 @@ func (m *TransportModule) Run(ctx context.Context) error {
 		port = defaultPort
 	}
 
-	srv := handler.New(graphqlgen.NewExecutableSchema(graphqlgen.Config{Resolvers: &graphql.Resolver{
-		GraphQLResolver: m.graphQLResolver,
-	}}))
+	srv := handler.New(graphqlgen.NewExecutableSchema(graphqlgen.Config{
+		Resolvers: &graphql.Resolver{
+			GraphQLResolver: m.graphQLResolver,
+		},
+		Complexity: newComplexityRoot(),
+	}))
+
+	srv.SetErrorPresenter(m.presentError)
+	srv.SetRecoverFunc(m.recoverPanic)
 
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.AddTransport(transport.Websocket{
+		InitFunc:              m.websocketInit,
+		KeepAlivePingInterval: websocketKeepAliveInterval,
+		Upgrader: websocket.Upgrader{
+			// Connections authenticate with the payload of their init message rather than cookies,
+			// so they are accepted from any origin.
+			CheckOrigin: func(r *http.Request) bool {
+				return true
+			},
+		},
+	})
 
 	m.middlewareOtel(srv)
+	m.middlewareLimit(srv)
 
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
 
 	srv.Use(extension.Introspection{})
-	srv.Use(extension.AutomaticPersistedQuery{
-		Cache: lru.New[string](100),
-	})
+	m.trustedDocuments.Install(srv)
 
 	http.Handle("/", playground.Handler("GraphQL playground", "/graphql"))
-	http.Handle("/graphql", m.middlewareDataloader(srv))
+	http.Handle("/graphql", m.middlewareAuth(m.middlewareLanguage(m.middlewareDataloader(srv))))
 
 	err := http.ListenAndServe(":"+port, nil)
 
@@ -2,11 +2,13 @@ package transportrest
 
 import (
 	"context"
+	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/contrib/otelfiber"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -17,19 +19,24 @@ import (
 
 	"gobase/config"
 	"gobase/di/registry"
+	productusecase "gobase/internal/domain/product/usecase"
 	middlewarerest "gobase/internal/pkg/middleware/rest"
+	"gobase/internal/pkg/service/authsvc"
+	"gobase/internal/pkg/service/blobsvc"
 )
 
 type TransportModule struct {
-	srv        *fiber.App
-	cfg        *config.MainConfig
-	restRouter *registry.RESTRouter
+	srv           *fiber.App
+	cfg           *config.MainConfig
+	restRouter    *registry.RESTRouter
+	authenticator authsvc.Authenticator
 }
 
-func NewTransport(cfg *config.MainConfig, restRouter *registry.RESTRouter) (registry.IApplicationTransportREST, registry.CleanupFunc) {
+func NewTransport(cfg *config.MainConfig, restRouter *registry.RESTRouter, authenticator authsvc.Authenticator) (registry.IApplicationTransportREST, registry.CleanupFunc) {
 	transportModule := &TransportModule{
-		cfg:        cfg,
-		restRouter: restRouter,
+		cfg:           cfg,
+		restRouter:    restRouter,
+		authenticator: authenticator,
 	}
 
 	return transportModule, transportModule.Cleanup
@@ -49,14 +56,29 @@ func (m *TransportModule) Run(ctx context.Context) error {
 	restConfig := m.cfg.Server.Rest
 	readTimeoutSecond := restConfig.ReadTimeoutSecond
 	writeTimeoutSecond := restConfig.WriteTimeoutSecond
-	bodyLimitMB := restConfig.BodyLimitMB
+	bodyLimit := restConfig.BodyLimitMB * 1024 * 1024
+	if bodyLimit <= 0 {
+		bodyLimit = fiber.DefaultBodyLimit
+	}
+
+	// Media uploads are refused by the body limit before their own size limit is checked.
+	mediaMaxSizeMB := m.cfg.Product.MediaMaxSizeMB
+	if mediaMaxSizeMB <= 0 {
+		mediaMaxSizeMB = productusecase.DefaultMediaMaxSizeMB
+	}
+	if bodyLimit <= mediaMaxSizeMB*1024*1024 {
+		log.Warn().
+			Int("bodyLimitMB", bodyLimit/1024/1024).
+			Int("mediaMaxSizeMB", mediaMaxSizeMB).
+			Msg("the REST body limit is not above the media size limit, large media uploads will be refused with 413")
+	}
 
 	jsoniterExtra.SetNamingStrategy(jsoniterExtra.LowerCaseWithUnderscores)
 	jsonHandler := jsoniter.ConfigCompatibleWithStandardLibrary
 
 	m.srv = fiber.New(fiber.Config{
 		ErrorHandler: middlewarerest.GetErrorMiddleware(),
-		BodyLimit:    bodyLimitMB * 1024 * 1024,
+		BodyLimit:    bodyLimit,
 		ReadTimeout:  time.Duration(readTimeoutSecond) * time.Second,
 		WriteTimeout: time.Duration(writeTimeoutSecond) * time.Second,
 		JSONEncoder:  jsonHandler.Marshal,
@@ -75,6 +97,14 @@ func (m *TransportModule) Run(ctx context.Context) error {
 	m.srv.Use(logger.New())
 	m.srv.Use(cors.New())
 	m.srv.Use(helmet.New())
+	m.srv.Use(middlewarerest.GetAuthMiddleware(m.authenticator))
+
+	m.srv.Post("/products/:id/media", m.restRouter.Product.UploadMedia)
+
+	// The local blob storage serves the files behind its signed URLs itself.
+	if handler, ok := m.restRouter.BlobStorage.(http.Handler); ok {
+		m.srv.Get(blobsvc.LocalRoutePrefix+"*", adaptor.HTTPHandler(handler))
+	}
 
 	err := m.srv.Listen(m.cfg.Server.Rest.ListenAddress + ":" + strconv.Itoa(m.cfg.Server.Rest.Port))
 	if err != nil {
@@ -0,0 +1,140 @@
+package transportscheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"gobase/config"
+	"gobase/di/registry"
+	inventoryusecase "gobase/internal/domain/inventory/usecase"
+	productusecase "gobase/internal/domain/product/usecase"
+	"gobase/internal/pkg/service/otelsvc"
+)
+
+const (
+	defaultReservationExpiryIntervalSecond = 60
+	defaultPriceScheduleIntervalSecond     = 60
+	defaultMediaCleanupIntervalSecond      = 300
+)
+
+// job is a task that runs on a fixed interval until the scheduler is stopped.
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+type TransportModule struct {
+	cfg              *config.MainConfig
+	inventoryUseCase inventoryusecase.UseCase
+	productUseCase   productusecase.UseCase
+	wg               sync.WaitGroup
+}
+
+type TransportOpts struct {
+	Config           *config.MainConfig
+	InventoryUseCase inventoryusecase.UseCase
+	ProductUseCase   productusecase.UseCase
+}
+
+func NewTransport(opts TransportOpts) (registry.IApplicationTransportScheduler, registry.CleanupFunc) {
+	transportModule := &TransportModule{
+		cfg:              opts.Config,
+		inventoryUseCase: opts.InventoryUseCase,
+		productUseCase:   opts.ProductUseCase,
+	}
+
+	return transportModule, transportModule.Cleanup
+}
+
+// Cleanup waits for the running jobs to finish.
+func (m *TransportModule) Cleanup() {
+	m.wg.Wait()
+}
+
+func (m *TransportModule) Run(ctx context.Context) error {
+	for _, j := range m.jobs() {
+		m.wg.Add(1)
+		go func(j job) {
+			defer m.wg.Done()
+			m.runJob(ctx, j)
+		}(j)
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func (m *TransportModule) jobs() []job {
+	expiryInterval := m.cfg.Inventory.ReservationExpiryIntervalSecond
+	if expiryInterval <= 0 {
+		expiryInterval = defaultReservationExpiryIntervalSecond
+	}
+
+	priceScheduleInterval := m.cfg.Product.PriceScheduleIntervalSecond
+	if priceScheduleInterval <= 0 {
+		priceScheduleInterval = defaultPriceScheduleIntervalSecond
+	}
+
+	mediaCleanupInterval := m.cfg.Product.MediaCleanupIntervalSecond
+	if mediaCleanupInterval <= 0 {
+		mediaCleanupInterval = defaultMediaCleanupIntervalSecond
+	}
+
+	return []job{
+		{
+			name:     "inventory.expire_reservations",
+			interval: time.Duration(expiryInterval) * time.Second,
+			run: func(ctx context.Context) error {
+				expired, err := m.inventoryUseCase.ExpireReservations(ctx)
+				if expired > 0 {
+					log.Info().Int("expired", expired).Msg("expired inventory reservations")
+				}
+				return err
+			},
+		},
+		{
+			name:     "product.publish_price_window_changes",
+			interval: time.Duration(priceScheduleInterval) * time.Second,
+			run: func(ctx context.Context) error {
+				published, err := m.productUseCase.PublishPriceWindowChanges(ctx)
+				if published > 0 {
+					log.Info().Int("published", published).Msg("published variant price changes")
+				}
+				return err
+			},
+		},
+		{
+			name:     "product.cleanup_orphan_media",
+			interval: time.Duration(mediaCleanupInterval) * time.Second,
+			run: func(ctx context.Context) error {
+				deleted, err := m.productUseCase.CleanupOrphanMedia(ctx)
+				if deleted > 0 {
+					log.Info().Int("deleted", deleted).Msg("deleted orphan product media")
+				}
+				return err
+			},
+		},
+	}
+}
+
+func (m *TransportModule) runJob(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			jobCtx, span := otelsvc.StartSpan(ctx, "Scheduler/"+j.name)
+			if err := j.run(jobCtx); err != nil {
+				log.Error().Err(err).Str("job", j.name).Msg("scheduled job failed")
+			}
+			span.End()
+		}
+	}
+}